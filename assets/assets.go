@@ -0,0 +1,9 @@
+// Package assets embeds the game's images, sprite manifest, string tables
+// and authored matches so the binary does not depend on the working
+// directory.
+package assets
+
+import "embed"
+
+//go:embed roupiks/atlas.png sprites.json lang/*.json all:matches
+var FS embed.FS
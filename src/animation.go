@@ -0,0 +1,218 @@
+package main
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type TweenKind int
+
+const (
+	TweenSlide TweenKind = iota
+	TweenShatter
+	TweenFlash
+)
+
+type Tween struct {
+	Kind     TweenKind
+	Tile     Tile
+	From     Position
+	To       Position
+	Delay    float64
+	Elapsed  float64
+	Duration float64
+}
+
+func (tween *Tween) Progress() float64 {
+	t := (tween.Elapsed - tween.Delay) / tween.Duration
+	return min(max(t, 0), 1)
+}
+
+func (tween *Tween) Started() bool {
+	return tween.Elapsed >= tween.Delay
+}
+
+// Animator turns move events into tweens and keeps the clip each board
+// piece is playing. It runs on wall-clock time, so animations play at the
+// same speed whatever the battle tick rate is.
+type Animator struct {
+	Time    float64
+	tweens  []Tween
+	pieces  map[Position]*PieceAnimation
+	capture *Position // the square of the capture the next move makes
+}
+
+// IdleStagger offsets the idle clip of neighbouring squares so the pieces do
+// not bob in step.
+const IdleStagger = 0.3
+
+// Piece returns the clip of the piece on the square. Pieces that have not
+// done anything play their idle clip.
+func (animator *Animator) Piece(pos Position) PieceAnimation {
+	if piece, ok := animator.pieces[pos]; ok {
+		return *piece
+	}
+	return PieceAnimation{Clip: ClipIdle, Elapsed: animator.Time + float64(pos.X+pos.Y)*IdleStagger}
+}
+
+func (animator *Animator) play(pos Position, tile Tile, clip Clip, elapsed float64) {
+	if animator.pieces == nil {
+		animator.pieces = map[Position]*PieceAnimation{}
+	}
+	animator.pieces[pos] = &PieceAnimation{
+		Sprite:  TileToSprite[tile.Color][tile.Piece],
+		Clip:    clip,
+		Elapsed: elapsed,
+	}
+}
+
+// PieceFrame is the frame the piece on the square shows.
+func (animator *Animator) PieceFrame(pos Position, tile Tile) SpriteFrame {
+	piece := animator.Piece(pos)
+	return ClipFrame(TileToSprite[tile.Color][tile.Piece], piece.Clip, max(piece.Elapsed, 0))
+}
+
+func (animator *Animator) HandleEvent(event Event) {
+	switch event.Kind {
+	case EventMove:
+		piece, moved := animator.pieces[event.Move.From]
+		delete(animator.pieces, event.Move.From)
+		delete(animator.pieces, event.Move.To)
+		if animator.capture != nil && *animator.capture == event.Move.To {
+			// the lunge starts once the piece has slid into place
+			animator.play(event.Move.To, event.Tile, ClipAttack, -SlideDuration)
+		} else if moved {
+			animator.pieces[event.Move.To] = piece
+		}
+		animator.capture = nil
+		animator.tweens = append(animator.tweens, Tween{
+			Kind:     TweenSlide,
+			Tile:     event.Tile,
+			From:     event.Move.From,
+			To:       event.Move.To,
+			Duration: SlideDuration,
+		})
+	case EventCapture:
+		animator.capture = &event.Position
+		animator.tweens = append(animator.tweens, Tween{
+			Kind:     TweenShatter,
+			Tile:     event.Tile,
+			From:     event.Position,
+			To:       event.Position,
+			Duration: ShatterDuration,
+		})
+	case EventPromotion:
+		animator.tweens = append(animator.tweens, Tween{
+			Kind:     TweenFlash,
+			Tile:     event.Tile,
+			From:     event.Position,
+			To:       event.Position,
+			Delay:    SlideDuration,
+			Duration: FlashDuration,
+		})
+	case EventSpell:
+		if event.Tile.Piece != PieceEmpty {
+			animator.play(event.Position, event.Tile, ClipHit, 0)
+		}
+	case EventPlace, EventRemove:
+		delete(animator.pieces, event.Position)
+	}
+}
+
+func (animator *Animator) Update(dt float64) {
+	animator.Time += dt
+	for pos, piece := range animator.pieces {
+		piece.Elapsed += dt
+		if piece.Clip.Loops() {
+			continue
+		}
+		// one-shot clips end on the idle loop
+		if piece.Elapsed >= ClipLength(piece.Sprite, piece.Clip) {
+			delete(animator.pieces, pos)
+		}
+	}
+	active := animator.tweens[:0]
+	for _, tween := range animator.tweens {
+		tween.Elapsed += dt
+		if tween.Elapsed < tween.Delay+tween.Duration {
+			active = append(active, tween)
+		}
+	}
+	animator.tweens = active
+}
+
+func (animator *Animator) Clear() {
+	animator.tweens = nil
+	animator.pieces = nil
+	animator.capture = nil
+}
+
+// Covers reports whether an animation is drawing the piece that stands on
+// the square, so the board should leave it out.
+func (animator *Animator) Covers(pos Position) bool {
+	for _, tween := range animator.tweens {
+		if tween.To != pos {
+			continue
+		}
+		if tween.Kind == TweenSlide || (tween.Kind == TweenFlash && tween.Started()) {
+			return true
+		}
+	}
+	return false
+}
+
+func easeOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+func (g *Game) DrawAnimations(screen *ebiten.Image) {
+	originX := float64(g.Graphics.Board.ScreenX)
+	originY := float64(g.Graphics.Board.ScreenY)
+
+	for _, tween := range g.Animator.tweens {
+		if !tween.Started() {
+			continue
+		}
+		sprite := Sprites[TileToSprite[tween.Tile.Color][tween.Tile.Piece]]
+		t := tween.Progress()
+
+		switch tween.Kind {
+		case TweenSlide:
+			e := easeOutQuad(t)
+			x := originX + (float64(tween.From.X)+float64(tween.To.X-tween.From.X)*e)*TileSize
+			y := originY + (float64(tween.From.Y)+float64(tween.To.Y-tween.From.Y)*e)*TileSize
+			opt := g.Graphics.Position(x, y)
+			screen.DrawImage(sprite, &opt)
+
+		case TweenShatter:
+			// the death clip breaks into four quarters that drift apart and fade
+			frame := ClipFrame(TileToSprite[tween.Tile.Color][tween.Tile.Piece], ClipDeath, tween.Elapsed-tween.Delay)
+			sprite = frame.Image
+			bounds := sprite.Bounds()
+			half := TileSize / 2
+			for qy := range 2 {
+				for qx := range 2 {
+					corner := bounds.Min.Add(image.Pt(qx*half, qy*half))
+					shard := sprite.SubImage(image.Rectangle{Min: corner, Max: corner.Add(image.Pt(half, half))}).(*ebiten.Image)
+					dx := float64(qx*2-1) * t * ShatterDistance
+					dy := float64(qy*2-1)*t*ShatterDistance + t*t*ShatterDistance
+					x := originX + float64(tween.To.X*TileSize+qx*half) + dx
+					y := originY + float64(tween.To.Y*TileSize+qy*half) + dy
+					opt := g.Graphics.Position(x, y)
+					opt.ColorScale.ScaleWithColorScale(frame.Tint)
+					opt.ColorScale.ScaleAlpha(float32(1 - t))
+					screen.DrawImage(shard, &opt)
+				}
+			}
+
+		case TweenFlash:
+			x := originX + float64(tween.To.X*TileSize)
+			y := originY + float64(tween.To.Y*TileSize)
+			opt := g.Graphics.Position(x, y)
+			glow := float32(1 + (1-t)*2)
+			opt.ColorScale.Scale(glow, glow, glow, 1)
+			screen.DrawImage(sprite, &opt)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+type Sound int
+
+const (
+	SoundPlace Sound = iota
+	SoundMove
+	SoundCapture
+	SoundPromotion
+	SoundVictory
+	SoundDefeat
+)
+
+type Track int
+
+const (
+	TrackNone Track = iota
+	TrackArrange
+	TrackBattle
+	TrackShop
+)
+
+// MusicScene is implemented by scenes that play a music track. Scenes
+// without one, such as overlays, keep the music of the scene beneath.
+type MusicScene interface {
+	Music() Track
+}
+
+// AudioManager plays sound effects in response to game events and loops the
+// music of the current scene. Sounds are rendered the first time they are
+// needed.
+type AudioManager struct {
+	context  *audio.Context
+	settings *Settings
+	sounds   map[Sound][]byte
+	players  map[Track]*audio.Player
+	track    Track
+	played   map[Sound]bool // sounds already started this frame
+}
+
+func NewAudioManager(settings *Settings) *AudioManager {
+	return &AudioManager{
+		context:  audio.NewContext(SampleRate),
+		settings: settings,
+		sounds:   map[Sound][]byte{},
+		players:  map[Track]*audio.Player{},
+		played:   map[Sound]bool{},
+	}
+}
+
+func (manager *AudioManager) HandleEvent(event Event) {
+	switch event.Kind {
+	case EventPlace:
+		manager.Play(SoundPlace)
+	case EventMove:
+		manager.Play(SoundMove)
+	case EventCapture:
+		manager.Play(SoundCapture)
+	case EventPromotion:
+		manager.Play(SoundPromotion)
+	case EventBattleEnd:
+		if event.Won {
+			manager.Play(SoundVictory)
+		} else {
+			manager.Play(SoundDefeat)
+		}
+	}
+}
+
+// Play starts a sound effect. A fast battle can make many moves in a frame,
+// so each sound starts at most once per frame.
+func (manager *AudioManager) Play(sound Sound) {
+	volume := manager.settings.SoundVolume
+	if manager.settings.Mute || volume <= 0 || manager.played[sound] {
+		return
+	}
+	manager.played[sound] = true
+	pcm, ok := manager.sounds[sound]
+	if !ok {
+		notes := soundNotes[sound]
+		length := 0.0
+		for _, note := range notes {
+			length = max(length, note.Start+note.Length)
+		}
+		pcm = renderNotes(notes, length)
+		manager.sounds[sound] = pcm
+	}
+	player := manager.context.NewPlayerF32FromBytes(pcm)
+	player.SetVolume(volume)
+	player.Play()
+}
+
+// Update switches to the music track and applies the volume settings.
+func (manager *AudioManager) Update(track Track) {
+	clear(manager.played)
+
+	if track != manager.track {
+		if player, ok := manager.players[manager.track]; ok {
+			player.Pause()
+		}
+		manager.track = track
+		if player := manager.player(track); player != nil {
+			player.Rewind()
+			player.Play()
+		}
+	}
+
+	if player, ok := manager.players[manager.track]; ok {
+		volume := manager.settings.MusicVolume
+		if manager.settings.Mute {
+			volume = 0
+		}
+		player.SetVolume(volume)
+	}
+}
+
+func (manager *AudioManager) player(track Track) *audio.Player {
+	if player, ok := manager.players[track]; ok {
+		return player
+	}
+	song, ok := songs[track]
+	if !ok {
+		return nil
+	}
+	pcm := song.Render()
+	player, err := manager.context.NewPlayerF32(audio.NewInfiniteLoopF32(bytes.NewReader(pcm), int64(len(pcm))))
+	if err != nil {
+		return nil
+	}
+	manager.players[track] = player
+	return player
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type BattleSpeed int
+
+const (
+	Speed1x BattleSpeed = iota
+	Speed2x
+	Speed4x
+	SpeedInstant
+)
+
+var speedMultipliers = map[BattleSpeed]float64{
+	Speed1x: 1,
+	Speed2x: 2,
+	Speed4x: 4,
+}
+
+var speedLabels = map[BattleSpeed]string{
+	Speed1x:      "1x",
+	Speed2x:      "2x",
+	Speed4x:      "4x",
+	SpeedInstant: ">>",
+}
+
+type BattleControl int
+
+const (
+	ControlPause BattleControl = iota
+	ControlStep
+	ControlSpeed1x
+	ControlSpeed2x
+	ControlSpeed4x
+	ControlSpeedInstant
+	battleControlCount
+)
+
+// plyDue reports whether the battle should advance this frame, given the
+// current speed and pause state. Instant battles are played by playInstant.
+func (g *Game) plyDue() bool {
+	if g.Paused || g.Effects.Stopped() {
+		return false
+	}
+	now := time.Now()
+	if now.Sub(g.PrevComputerTime).Seconds() < 1/(ComputerFPS*speedMultipliers[g.Speed]) {
+		return false
+	}
+	g.PrevComputerTime = now
+	return true
+}
+
+var battleControlTooltips = map[BattleControl]string{
+	ControlPause:        "battle.pause",
+	ControlStep:         "battle.step",
+	ControlSpeed1x:      "battle.speed_1x",
+	ControlSpeed2x:      "battle.speed_2x",
+	ControlSpeed4x:      "battle.speed_4x",
+	ControlSpeedInstant: "battle.speed_instant",
+}
+
+// BattleControls is the row of pause, step and speed buttons shown during a
+// battle.
+type BattleControls struct {
+	Panel   *Panel
+	buttons [battleControlCount]*Button
+	pressed BattleControl
+}
+
+func NewBattleControls() *BattleControls {
+	controls := &BattleControls{pressed: battleControlCount}
+	width := int(battleControlCount)*(TileSize+2) - 2
+	controls.Panel = &Panel{Base: Base{Layout: Layout{Anchor: AnchorTop, Y: 200, W: width, H: TileSize}}}
+
+	for control := range battleControlCount {
+		button := &Button{
+			Base: Base{
+				Layout:  Layout{X: int(control) * (TileSize + 2), W: TileSize, H: TileSize},
+				Tooltip: T(battleControlTooltips[control]),
+				OnClick: func() { controls.pressed = control },
+			},
+			Sprite: Sprites[SpriteButtonSmall],
+		}
+		switch control {
+		case ControlPause:
+		case ControlStep:
+			button.Text = ">"
+		default:
+			button.Text = speedLabels[BattleSpeed(control-ControlSpeed1x)]
+		}
+		controls.buttons[control] = button
+		controls.Panel.Widgets = append(controls.Panel.Widgets, button)
+	}
+	return controls
+}
+
+func (controls *BattleControls) Refresh(g *Game) {
+	controls.buttons[ControlPause].Icon = Sprites[SpriteIconPause]
+	if g.Paused {
+		controls.buttons[ControlPause].Icon = Sprites[SpriteIconPlay]
+	}
+	for speed := Speed1x; speed <= SpeedInstant; speed++ {
+		controls.buttons[ControlSpeed1x+BattleControl(speed)].Selected = speed == g.Speed
+	}
+}
+
+// UpdateBattleSpeed handles the pause, step and speed controls. It returns
+// true when a single step was requested.
+func (g *Game) UpdateBattleSpeed(controls *BattleControls) bool {
+	step := false
+	pressed := controls.pressed
+	controls.pressed = battleControlCount
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyP) || pressed == ControlPause:
+		g.Paused = !g.Paused
+	case inpututil.IsKeyJustPressed(ebiten.KeyPeriod) || pressed == ControlStep:
+		g.Paused = true
+		step = true
+	case inpututil.IsKeyJustPressed(ebiten.Key1) || pressed == ControlSpeed1x:
+		g.Speed = Speed1x
+	case inpututil.IsKeyJustPressed(ebiten.Key2) || pressed == ControlSpeed2x:
+		g.Speed = Speed2x
+	case inpututil.IsKeyJustPressed(ebiten.Key3) || pressed == ControlSpeed4x:
+		g.Speed = Speed4x
+	case inpututil.IsKeyJustPressed(ebiten.Key4) || pressed == ControlSpeedInstant:
+		g.Speed = SpeedInstant
+	}
+
+	if g.Debug && inpututil.IsKeyJustPressed(ebiten.KeyComma) {
+		g.Paused = true
+		g.StepBack()
+	}
+	return step
+}
+
+// playInstant plays plies until the battle is decided or the frame's
+// InstantFrameBudget is spent. It always plays at least one ply and does not
+// wait for hit-stops.
+func (g *Game) playInstant() ObjectiveStatus {
+	start := time.Now()
+	for {
+		status := g.PlayPly()
+		if status != ObjectivePending || time.Since(start).Seconds() >= InstantFrameBudget {
+			return status
+		}
+	}
+}
+
+// StepBack restores the board from before the last ply. Only the board is
+// rewound; gold and unit experience earned since are kept.
+func (g *Game) StepBack() {
+	if len(g.History) == 0 {
+		return
+	}
+	g.Board = g.History[len(g.History)-1]
+	g.History = g.History[:len(g.History)-1]
+	g.Intent = Intent{}
+	g.Animator.Clear()
+}
+
+// DrawBattleSpeed shows the ply count while debugging; the controls
+// themselves are widgets.
+func (g *Game) DrawBattleSpeed(screen *ebiten.Image) {
+	if g.Debug {
+		g.Graphics.DrawText(screen, T("battle.ply", "count", len(g.History)), 8, 188)
+	}
+}
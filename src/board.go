@@ -1,12 +1,24 @@
 package main
 
 import (
+	"fmt"
 	"math/rand"
+	"strings"
 )
 
 type Board struct {
-	Tiles [BoardHeight][BoardWidth]Tile
-	Turn  int
+	Tiles   [BoardHeight][BoardWidth]Tile
+	Terrain [BoardHeight][BoardWidth]Terrain
+	Rules   [2]Rules
+	Turn    int
+
+	// set while a king-flagged piece is taking its second move, which only
+	// the piece on ExtraMover may make
+	ExtraMove  bool
+	ExtraMover Position
+
+	// plies left before a temporary wall crumbles, 0 for permanent terrain
+	TerrainTimer [BoardHeight][BoardWidth]int
 }
 
 func (board *Board) Color() Color {
@@ -17,9 +29,42 @@ func (board *Board) Color() Color {
 }
 
 type Tile struct {
-	Piece Piece
-	Color Color
-	King  bool
+	Piece  Piece
+	Color  Color
+	King   bool
+	Level  int
+	Frozen int // own turns left to sit out
+	Unit   UnitID
+}
+
+type Terrain int
+
+const (
+	TerrainFloor Terrain = iota
+	TerrainWall
+)
+
+func (board *Board) isWall(x, y int) bool {
+	return board.Terrain[y][x] == TerrainWall
+}
+
+// canPlace reports whether the player may put a piece on the square during
+// the arrange phase: an empty floor square in the bottom PlacementRows rows.
+func (board *Board) canPlace(x, y int) bool {
+	return y >= BoardHeight-PlacementRows && board.Tiles[y][x].Piece == PieceEmpty && !board.isWall(x, y)
+}
+
+// placementSquares lists the squares a hand card can be put on.
+func (board *Board) placementSquares() []Position {
+	squares := []Position{}
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			if board.canPlace(x, y) {
+				squares = append(squares, Position{X: x, Y: y})
+			}
+		}
+	}
+	return squares
 }
 
 type Color int
@@ -29,12 +74,16 @@ const (
 	Black
 )
 
-func randomColor() Color {
-	colors := []Color{
-		White,
-		Black,
+var colorNames = map[Color]string{
+	White: "color.white",
+	Black: "color.black",
+}
+
+func (color Color) Opponent() Color {
+	if color == White {
+		return Black
 	}
-	return colors[rand.Intn(len(colors))]
+	return White
 }
 
 type Piece int
@@ -47,9 +96,25 @@ const (
 	PieceRook
 	PieceQueen
 	PieceKing
+	pieceCount
 )
 
-func randomPiece() Piece {
+var pieceNames = map[Piece]string{
+	PieceEmpty:  "Empty",
+	PiecePawn:   "Pawn",
+	PieceKnight: "Knight",
+	PieceBishop: "Bishop",
+	PieceRook:   "Rook",
+	PieceQueen:  "Queen",
+	PieceKing:   "King",
+}
+
+// PieceName is the piece's name in the active language.
+func PieceName(piece Piece) string {
+	return T("piece." + strings.ToLower(pieceNames[piece]))
+}
+
+func randomPiece(rng *rand.Rand) Piece {
 	pieces := []Piece{
 		PieceEmpty,
 		PiecePawn,
@@ -59,7 +124,7 @@ func randomPiece() Piece {
 		PieceKing,
 		PieceQueen,
 	}
-	return pieces[rand.Intn(len(pieces))]
+	return pieces[rng.Intn(len(pieces))]
 }
 
 type Position struct {
@@ -67,19 +132,91 @@ type Position struct {
 	Y int
 }
 
+func (position Position) String() string {
+	return fmt.Sprintf("%c%d", 'a'+position.X, BoardHeight-position.Y)
+}
+
 type Move struct {
 	From Position
 	To   Position
 }
 
-func ApplyMove(board *Board, move Move) {
+// MoveResult describes what ApplyMove did, so the game can react to it.
+// Mover is the piece as it stood before the move.
+type MoveResult struct {
+	Move     Move
+	Mover    Tile
+	Captured Tile
+	Promoted bool
+}
+
+func ApplyMove(board *Board, move Move) MoveResult {
 	tile := board.Tiles[move.From.Y][move.From.X]
+	result := MoveResult{
+		Move:     move,
+		Mover:    tile,
+		Captured: board.Tiles[move.To.Y][move.To.X],
+	}
 
-	if tile.Piece == PiecePawn && (move.To.Y == 0 || move.To.Y == BoardHeight-1) {
+	if tile.Piece == PiecePawn && board.isPromotionRow(tile.Color, move.To.Y) {
 		tile.Piece = PieceQueen
+		result.Promoted = true
 	}
 
 	board.Tiles[move.To.Y][move.To.X] = tile
 	board.Tiles[move.From.Y][move.From.X] = Tile{Piece: PieceEmpty}
+
+	if tile.King && board.Rules[tile.Color].KingDoubleMove && !board.ExtraMove {
+		board.ExtraMove = true
+		board.ExtraMover = move.To
+		board.tickTerrain()
+		return result
+	}
+	board.ExtraMove = false
+	board.endPly()
+	return result
+}
+
+// PassTurn is used when the side to move has no legal moves.
+func (board *Board) PassTurn() {
+	board.ExtraMove = false
+	board.endPly()
+}
+
+// endPly hands the move to the other side. Frozen pieces count the turns of
+// their own side, so a double move thaws them only once.
+func (board *Board) endPly() {
+	color := board.Color()
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			tile := &board.Tiles[y][x]
+			if tile.Frozen > 0 && tile.Color == color {
+				tile.Frozen -= 1
+			}
+		}
+	}
+	board.tickTerrain()
 	board.Turn += 1
 }
+
+// tickTerrain counts down temporary walls, which last a number of plies.
+func (board *Board) tickTerrain() {
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			if board.TerrainTimer[y][x] > 0 {
+				board.TerrainTimer[y][x] -= 1
+				if board.TerrainTimer[y][x] == 0 {
+					board.Terrain[y][x] = TerrainFloor
+				}
+			}
+		}
+	}
+}
+
+func (board *Board) isPromotionRow(color Color, y int) bool {
+	early := board.Rules[color].PromotionRanksEarly
+	if color == White {
+		return y <= early
+	}
+	return y >= BoardHeight-1-early
+}
@@ -25,7 +25,8 @@ func evaluate(board *Board, color Color) float64 {
 	for y := range BoardHeight {
 		for x := range BoardWidth {
 			tile := board.Tiles[y][x]
-			value := pieceScores[tile.Piece]
+			value := pieceScores[tile.Piece] + board.Rules[tile.Color].PieceValueBonus[tile.Piece]
+			value *= 1 + LevelValueBonus*float64(tile.Level)
 			if tile.King {
 				value = kingScore
 			}
@@ -44,12 +45,13 @@ func (board* Board) isCaptureMove(move Move) bool {
 	return  board.Tiles[move.To.Y][move.To.X].Piece != PieceEmpty
 }
 
-func negamax(board *Board, depth int, alpha, beta float64) (Move, float64, bool) {
+// negamax returns the principal variation, the line both sides are expected
+// to play, along with its value for the side to move.
+func negamax(board *Board, depth int, alpha, beta float64) ([]Move, float64, bool) {
 	color := board.Color()
-	empty_move := Move{}
 
 	if depth == 0 {
-		return empty_move, evaluate(board, color), false
+		return nil, evaluate(board, color), false
 	}
 
 	moves := generateMovesForColor(board, color)
@@ -63,22 +65,29 @@ func negamax(board *Board, depth int, alpha, beta float64) (Move, float64, bool)
 		return rand.Intn(3) - 1
 	})
 	if len(moves) == 0 {
-		return empty_move, evaluate(board, color), false
+		return nil, evaluate(board, color), false
 	}
 
 	best_value := math.Inf(-1)
-	best_move := empty_move
+	best_line := []Move{}
 
 	for _, move := range moves {
 		child := *board
 		ApplyMove(&child, move)
 
-		_, value, _ := negamax(&child, depth-1, -beta, -alpha)
-		value = -value
+		line := []Move{}
+		value := 0.0
+		if child.Color() == color {
+			// the same side moves again, so there is no perspective flip
+			line, value, _ = negamax(&child, depth-1, alpha, beta)
+		} else {
+			line, value, _ = negamax(&child, depth-1, -beta, -alpha)
+			value = -value
+		}
 
 		if value > best_value {
 			best_value = value
-			best_move = move
+			best_line = append([]Move{move}, line...)
 		}
 
 		alpha = math.Max(alpha, best_value)
@@ -87,12 +96,20 @@ func negamax(board *Board, depth int, alpha, beta float64) (Move, float64, bool)
 		}
 	}
 
-	return best_move, best_value, true
+	return best_line, best_value, true
 }
 
 func ComputeMove(board *Board, depth int) (Move, bool) {
+	line, ok := ComputeLine(board, depth)
+	if !ok {
+		return Move{}, false
+	}
+	return line[0], true
+}
+
+func ComputeLine(board *Board, depth int) ([]Move, bool) {
 	alpha := math.Inf(-1)
 	beta := math.Inf(1)
-	move, _, ok := negamax(board, depth, alpha, beta)
-	return move, ok
+	line, _, ok := negamax(board, depth, alpha, beta)
+	return line, ok
 }
@@ -1,43 +1,39 @@
 package main
 
 import (
-	"math"
-	"math/rand"
-
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 type GraphicsBoard struct {
 	ScreenX, ScreenY int
-	ShakeDuration    int
 }
 
 func (g *Game) DrawBoard(screen *ebiten.Image) {
 	graphicsBoard := g.Graphics.Board
 	board := g.Board
 
-	shakeOffsetX := 0.0
-	shakeOffsetY := 0.0
-	if graphicsBoard.ShakeDuration > 0 {
-		shakeOffsetX = (rand.Float64() - 0.5) * 1
-		shakeOffsetY = (rand.Float64() - 0.5) * 1
-		graphicsBoard.ShakeDuration -= 1
-	}
-
 	for y := range BoardHeight {
 		for x := range BoardWidth {
-			px := float64((x * TileSize) + graphicsBoard.ScreenX + int(math.Ceil(shakeOffsetX)))
-			py := float64((y * TileSize) + graphicsBoard.ScreenY + int(math.Ceil(shakeOffsetY)))
+			px := float64((x * TileSize) + graphicsBoard.ScreenX)
+			py := float64((y * TileSize) + graphicsBoard.ScreenY)
 
 			opTile := g.Graphics.Position(px, py)
+			if board.isWall(x, y) {
+				opTile.ColorScale.Scale(0.3, 0.3, 0.3, 1)
+			}
 			if (x+y)%2 == 0 {
 				screen.DrawImage(Sprites[SpriteTileBlack], &opTile)
 			} else {
 				screen.DrawImage(Sprites[SpriteTileWhite], &opTile)
 			}
 
+			if g.Objective.Kind == ObjectiveEscort && g.Objective.Target == (Position{X: x, Y: y}) {
+				screen.DrawImage(Sprites[SpriteHover], &opTile)
+			}
+
 			tile := board.Tiles[y][x]
-			if tile.Piece == PieceEmpty {
+			pos := Position{X: x, Y: y}
+			if tile.Piece == PieceEmpty || g.Animator.Covers(pos) || g.Drag.Hides(pos) {
 				continue
 			}
 
@@ -46,9 +42,12 @@ func (g *Game) DrawBoard(screen *ebiten.Image) {
 			if tile.King {
 				opPiece.ColorScale.Scale(1.25, 1.25, 0.5, 1)
 			}
+			if tile.Frozen > 0 {
+				opPiece.ColorScale.Scale(0.6, 0.8, 1.4, 1)
+			}
 
-			spriteID := TileToSprite[tile.Color][tile.Piece]
-			screen.DrawImage(Sprites[spriteID], &opPiece)
+			g.Graphics.DrawFrame(screen, g.Animator.PieceFrame(pos, tile), opPiece, tile.Color)
+			g.Graphics.DrawLevelBadge(screen, px, py, tile.Level)
 
 		}
 	}
@@ -57,6 +56,9 @@ func (g *Game) DrawBoard(screen *ebiten.Image) {
 func ScreenToTile(board *GraphicsBoard, x, y int) (int, int, bool) {
 	x -= board.ScreenX
 	y -= board.ScreenY
+	if x < 0 || y < 0 {
+		return -1, -1, false
+	}
 	x = x / TileSize
 	y = y / TileSize
 
@@ -7,6 +7,9 @@ func filterSelfCaptures(board *Board, moves []Move) []Move {
 	for _, move := range moves {
 		toTile := board.Tiles[move.To.Y][move.To.X]
 		fromTile := board.Tiles[move.From.Y][move.From.X]
+		if board.isWall(move.To.X, move.To.Y) {
+			continue
+		}
 		if toTile.Piece == PieceEmpty || toTile.Color != fromTile.Color {
 			filtered = append(filtered, move)
 		}
@@ -15,74 +18,71 @@ func filterSelfCaptures(board *Board, moves []Move) []Move {
 
 }
 
-func getRookMoves(board *Board, x, y int) []Move {
+// getSlideMoves walks from (x, y) in one direction until it leaves the
+// board, hits a wall, or has passed more than pierce pieces.
+func getSlideMoves(board *Board, x, y, dx, dy, pierce int) []Move {
 	moves := []Move{}
+	blockers := 0
 
-	for i := y - 1; i >= 0; i-- {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: x, Y: i}})
-		if board.Tiles[i][x].Piece != PieceEmpty {
+	for i, j := y+dy, x+dx; i >= 0 && i < BoardHeight && j >= 0 && j < BoardWidth; i, j = i+dy, j+dx {
+		if board.isWall(j, i) {
 			break
 		}
-	}
-
-	for i := y + 1; i < BoardHeight; i++ {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: x, Y: i}})
-		if board.Tiles[i][x].Piece != PieceEmpty {
-			break
+		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: i}})
+		if board.Tiles[i][j].Piece != PieceEmpty {
+			blockers += 1
+			if blockers > pierce {
+				break
+			}
 		}
 	}
+	return moves
+}
 
-	for j := x - 1; j >= 0; j-- {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: y}})
-		if board.Tiles[y][j].Piece != PieceEmpty {
-			break
-		}
+func getRookMoves(board *Board, x, y int) []Move {
+	moves := []Move{}
+
+	directions := []struct{ dx, dy int }{
+		{dx: 0, dy: -1},
+		{dx: 0, dy: 1},
+		{dx: -1, dy: 0},
+		{dx: 1, dy: 0},
 	}
 
-	for j := x + 1; j < BoardWidth; j++ {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: y}})
-		if board.Tiles[y][j].Piece != PieceEmpty {
-			break
-		}
+	for _, dir := range directions {
+		moves = append(moves, getSlideMoves(board, x, y, dir.dx, dir.dy, 0)...)
 	}
 	return filterSelfCaptures(board, moves)
 }
 
 func getBishopMoves(board *Board, x, y int) []Move {
 	moves := []Move{}
-
-	for i, j := y-1, x-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: i}})
-		if board.Tiles[i][j].Piece != PieceEmpty {
-			break
-		}
-	}
-
-	for i, j := y-1, x+1; i >= 0 && j < BoardWidth; i, j = i-1, j+1 {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: i}})
-		if board.Tiles[i][j].Piece != PieceEmpty {
-			break
-		}
+	tile := board.Tiles[y][x]
+	pierce := 0
+	if tile.Piece == PieceBishop {
+		pierce = board.Rules[tile.Color].BishopPierce + tile.Level
 	}
 
-	for i, j := y+1, x-1; i < BoardHeight && j >= 0; i, j = i+1, j-1 {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: i}})
-		if board.Tiles[i][j].Piece != PieceEmpty {
-			break
-		}
+	directions := []struct{ dx, dy int }{
+		{dx: -1, dy: -1},
+		{dx: 1, dy: -1},
+		{dx: -1, dy: 1},
+		{dx: 1, dy: 1},
 	}
 
-	for i, j := y+1, x+1; i < BoardHeight && j < BoardWidth; i, j = i+1, j+1 {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: j, Y: i}})
-		if board.Tiles[i][j].Piece != PieceEmpty {
-			break
-		}
+	for _, dir := range directions {
+		moves = append(moves, getSlideMoves(board, x, y, dir.dx, dir.dy, pierce)...)
 	}
 	return filterSelfCaptures(board, moves)
 }
 
 func getKnightMoves(board *Board, x, y int) []Move {
 	moves := []Move{}
+	tile := board.Tiles[y][x]
+	leaps := 1
+	if tile.Piece == PieceKnight {
+		leaps += board.Rules[tile.Color].KnightExtraLeaps + tile.Level
+	}
 
 	directions := []struct{ dx, dy int }{
 		{dx: -2, dy: -1},
@@ -96,10 +96,18 @@ func getKnightMoves(board *Board, x, y int) []Move {
 	}
 
 	for _, dir := range directions {
-		newX := x + dir.dx
-		newY := y + dir.dy
-		if newX >= 0 && newX < BoardWidth && newY >= 0 && newY < BoardHeight {
+		// extra leaps repeat the jump in the same direction while the
+		// squares landed on are empty
+		for leap := 1; leap <= leaps; leap++ {
+			newX := x + dir.dx*leap
+			newY := y + dir.dy*leap
+			if newX < 0 || newX >= BoardWidth || newY < 0 || newY >= BoardHeight {
+				break
+			}
 			moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: newX, Y: newY}})
+			if board.Tiles[newY][newX].Piece != PieceEmpty || board.isWall(newX, newY) {
+				break
+			}
 		}
 	}
 	return filterSelfCaptures(board, moves)
@@ -113,8 +121,12 @@ func getPawnMoves(board *Board, x, y int, color Color) []Move {
 	}
 
 	newY := y + direction
-	if newY >= 0 && newY < BoardHeight && board.Tiles[newY][x].Piece == PieceEmpty {
-		moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: x, Y: newY}})
+	if newY >= 0 && newY < BoardHeight && !board.isWall(x, newY) {
+		ahead := board.Tiles[newY][x]
+		// upgraded pawns may also capture straight ahead
+		if ahead.Piece == PieceEmpty || (board.Tiles[y][x].Level > 0 && ahead.Color != color) {
+			moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: x, Y: newY}})
+		}
 	}
 	// Diagonals
 	for _, dx := range []int{-1, 1} {
@@ -154,6 +166,21 @@ func getKingMoves(board *Board, x, y int) []Move {
 	return filterSelfCaptures(board, moves)
 }
 
+func getDiagonalStepMoves(board *Board, x, y int) []Move {
+	moves := []Move{}
+
+	for _, dx := range []int{-1, 1} {
+		for _, dy := range []int{-1, 1} {
+			newX := x + dx
+			newY := y + dy
+			if newX >= 0 && newX < BoardWidth && newY >= 0 && newY < BoardHeight {
+				moves = append(moves, Move{From: Position{X: x, Y: y}, To: Position{X: newX, Y: newY}})
+			}
+		}
+	}
+	return filterSelfCaptures(board, moves)
+}
+
 func getMoves(board *Board, x, y int) []Move {
 	tile := board.Tiles[y][x]
 	switch tile.Piece {
@@ -164,10 +191,16 @@ func getMoves(board *Board, x, y int) []Move {
 	case PieceBishop:
 		return getBishopMoves(board, x, y)
 	case PieceRook:
+		if tile.Level > 0 {
+			return append(getRookMoves(board, x, y), getDiagonalStepMoves(board, x, y)...)
+		}
 		return getRookMoves(board, x, y)
 	case PieceQueen:
 		return append(getRookMoves(board, x, y), getBishopMoves(board, x, y)...)
 	case PieceKing:
+		if tile.Level > 0 {
+			return append(getKingMoves(board, x, y), getKnightMoves(board, x, y)...)
+		}
 		return getKingMoves(board, x, y)
 	default:
 		return []Move{}
@@ -179,7 +212,10 @@ func generateMovesForColor(board *Board, color Color) []Move {
 	for y := range BoardHeight {
 		for x := range BoardWidth {
 			t := board.Tiles[y][x]
-			if t.Piece == PieceEmpty || t.Color != color {
+			if t.Piece == PieceEmpty || t.Color != color || t.Frozen > 0 {
+				continue
+			}
+			if board.ExtraMove && (Position{X: x, Y: y}) != board.ExtraMover {
 				continue
 			}
 			moves = append(moves, getMoves(board, x, y)...)
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Clip is one of the frame animations a piece sprite can play.
+type Clip int
+
+const (
+	ClipIdle Clip = iota
+	ClipAttack
+	ClipHit
+	ClipDeath
+)
+
+// clipNames maps each clip to its name in the sprite manifest.
+var clipNames = map[Clip]string{
+	ClipIdle:   "idle",
+	ClipAttack: "attack",
+	ClipHit:    "hit",
+	ClipDeath:  "death",
+}
+
+// Loops reports whether the clip repeats. The others play once.
+func (clip Clip) Loops() bool {
+	return clip == ClipIdle
+}
+
+func ClipLength(id SpriteID, clip Clip) float64 {
+	length := 0.0
+	for _, frame := range SpriteClips[id][clip] {
+		length += frame.Duration
+	}
+	return length
+}
+
+// ClipFrame returns the frame a clip shows after elapsed seconds. Sprites
+// without the clip show their static image.
+func ClipFrame(id SpriteID, clip Clip, elapsed float64) SpriteFrame {
+	frames := SpriteClips[id][clip]
+	if len(frames) == 0 {
+		return SpriteFrame{Image: Sprites[id]}
+	}
+	if clip.Loops() {
+		elapsed = math.Mod(elapsed, ClipLength(id, clip))
+	}
+	for _, frame := range frames {
+		if elapsed < frame.Duration {
+			return frame
+		}
+		elapsed -= frame.Duration
+	}
+	return frames[len(frames)-1]
+}
+
+// PieceAnimation is the clip a board piece is playing.
+type PieceAnimation struct {
+	Sprite  SpriteID
+	Clip    Clip
+	Elapsed float64
+}
+
+// DrawFrame draws a frame with the options of the tile it stands on. Black
+// pieces face down the board, so their offsets are mirrored.
+func (graphics *Graphics) DrawFrame(screen *ebiten.Image, frame SpriteFrame, opt ebiten.DrawImageOptions, color Color) {
+	dy := frame.Offset.Y
+	if color == Black {
+		dy = -dy
+	}
+	opt.GeoM.Translate(float64(frame.Offset.X), float64(dy))
+	opt.ColorScale.ScaleWithColorScale(frame.Tint)
+	screen.DrawImage(frame.Image, &opt)
+}
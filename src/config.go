@@ -8,7 +8,63 @@ const BoardHeight = 8
 const LayoutWidth = 320
 const LayoutHeight = 240
 
-const SpriteAtlasPath = "../assets/roupiks/atlas.png"
+const MatchDirPath = "matches" // in assets.FS, and in the user config directory
+const ConfigDirName = "chess-battles"
+const LangDirPath = "lang"
 const ComputerFPS = 3.0
+const InstantFrameBudget = 0.01 // seconds of battle search per frame at instant speed
 
 const TurnsPerLevel = 10
+const MaxBattleTurns = 100
+
+const MatchBudgetBase = 4.0
+const MatchBudgetPerMatch = 2.0
+const GenerateMatchAttempts = 32
+
+const MaxCardLevel = 2
+const LevelValueBonus = 0.5
+
+const FreezeTurns = 2
+const WallTurns = 3
+
+const RunLength = 10
+const MaxLosses = 3
+
+const StartingGold = 3
+const MatchRewardGold = 3
+const BountyGold = 2
+const WarChestGold = 2
+
+const EliteMatchInterval = 5
+const EliteBudgetBonus = 6.0
+
+const XPPerKill = 2
+const XPPerBattle = 1
+const XPPerRank = 5
+
+const ForecastBattles = 64
+const ForecastDepth = 2
+
+const OptimizerIterations = 300
+const OptimizerTemperature = 2.0
+const OptimizerDepth = 2
+
+const SlideDuration = 0.2
+const ShatterDuration = 0.4
+const ShatterDistance = 6.0
+const FlashDuration = 0.4
+
+const ShakeDecay = 8.0
+const ShakeFrequency = 30.0
+const ShakeCapture = 2.0
+const ShakeRemove = 1.0
+const HitStopCapture = 0.08
+const FlashAlpha = 0.35
+
+const SampleRate = 44100
+const VolumeStep = 0.1
+
+const TooltipDelay = 0.4
+const TooltipWidth = 180
+
+const PlacementRows = 3
@@ -1,22 +1,13 @@
 package main
 
-import (
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
-)
-
-func (g *Game) DrawControl(screen *ebiten.Image) {
-	opt := g.Graphics.Position(LayoutWidth/2-TileSize*3/2, 200)
-
-	spriteId := SpritePlayButton
-	screen.DrawImage(Sprites[spriteId], &opt)
-}
-
-func (g *Game) UpdateControl() {
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		if x > LayoutWidth/2-TileSize*3/2 && x < LayoutWidth/2+TileSize*3/2 && y > 200 && y < 200+TileSize {
-			g.State = StatePlay
-		}
+// NewPlayButton creates the button that starts a battle.
+func NewPlayButton(onClick func()) *Button {
+	return &Button{
+		Base: Base{
+			Layout:  Layout{Anchor: AnchorTop, Y: 200, W: TileSize * 3, H: TileSize},
+			Tooltip: T("control.play"),
+			OnClick: onClick,
+		},
+		Sprite: Sprites[SpritePlayButton],
 	}
 }
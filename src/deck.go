@@ -1,10 +1,78 @@
 package main
 
+import "slices"
+
 type Card struct {
 	Piece Piece
+	Spell Spell // SpellNone for unit cards
+	Level int   // number of merges, 0 for a base card
+	Unit  UnitID
+}
+
+func (card Card) IsSpell() bool {
+	return card.Spell != SpellNone
+}
+
+func (card Card) Name() string {
+	name := PieceName(card.Piece)
+	if card.IsSpell() {
+		name = T(cardEffects[card.Spell].Name())
+	}
+	if card.Level > 0 {
+		name = T("card.level", "name", name, "level", card.Level)
+	}
+	return name
 }
 
 type Deck struct {
 	Cards     []Card
 	DrawCount int // how many cards the player draws at the start of the turn
 }
+
+// MaxLevel is the level past which the card no longer merges.
+func (card Card) MaxLevel() int {
+	if card.IsSpell() {
+		return cardEffects[card.Spell].MaxLevel()
+	}
+	return MaxCardLevel
+}
+
+func (card Card) sameKind(other Card) bool {
+	return card.Piece == other.Piece && card.Spell == other.Spell && card.Level == other.Level
+}
+
+// mergeCards replaces every three identical cards below their MaxLevel with a
+// single card one level higher, repeating until no more merges are possible.
+// The first of the three cards is kept, and onMerge is told which cards it
+// absorbed.
+func mergeCards(cards []Card, onMerge func(kept Card, absorbed []Card)) []Card {
+	for {
+		merged := false
+		for i, card := range cards {
+			if card.Level >= card.MaxLevel() {
+				continue
+			}
+			matches := []int{i}
+			for j := i + 1; j < len(cards) && len(matches) < 3; j++ {
+				if cards[j].sameKind(card) {
+					matches = append(matches, j)
+				}
+			}
+			if len(matches) < 3 {
+				continue
+			}
+
+			cards[i].Level += 1
+			if onMerge != nil {
+				onMerge(cards[i], []Card{cards[matches[1]], cards[matches[2]]})
+			}
+			cards = slices.Delete(cards, matches[2], matches[2]+1)
+			cards = slices.Delete(cards, matches[1], matches[1]+1)
+			merged = true
+			break
+		}
+		if !merged {
+			return cards
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeCards(t *testing.T) {
+	pawn := Card{Piece: PiecePawn}
+	knight := Card{Piece: PieceKnight}
+	pawns := func(level, n int) []Card {
+		return slices.Repeat([]Card{{Piece: PiecePawn, Level: level}}, n)
+	}
+	spells := func(spell Spell, n int) []Card {
+		return slices.Repeat([]Card{{Spell: spell}}, n)
+	}
+
+	tests := []struct {
+		name  string
+		cards []Card
+		want  []Card
+	}{
+		{"two pawns", pawns(0, 2), pawns(0, 2)},
+		{"three pawns", pawns(0, 3), pawns(1, 1)},
+		{"four pawns", pawns(0, 4), []Card{{Piece: PiecePawn, Level: 1}, pawn}},
+		{"nine pawns", pawns(0, 9), pawns(2, 1)},
+		{"max level", pawns(MaxCardLevel, 3), pawns(MaxCardLevel, 3)},
+		{"mixed levels", []Card{pawn, pawn, {Piece: PiecePawn, Level: 1}}, []Card{pawn, pawn, {Piece: PiecePawn, Level: 1}}},
+		{"mixed pieces", []Card{pawn, knight, pawn, knight, pawn}, []Card{{Piece: PiecePawn, Level: 1}, knight, knight}},
+		{"freeze", spells(SpellFreeze, 3), []Card{{Spell: SpellFreeze, Level: 1}}},
+		{"wall", spells(SpellWall, 9), []Card{{Spell: SpellWall, Level: 2}}},
+		{"swap", spells(SpellSwap, 3), spells(SpellSwap, 3)},
+		{"promote", spells(SpellPromote, 3), spells(SpellPromote, 3)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mergeCards(test.cards, nil); !slices.Equal(got, test.want) {
+				t.Errorf("mergeCards() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMergeCardsReportsAbsorbedUnits(t *testing.T) {
+	cards := []Card{{Piece: PiecePawn, Unit: 1}, {Piece: PieceKnight, Unit: 2}, {Piece: PiecePawn, Unit: 3}, {Piece: PiecePawn, Unit: 4}}
+	var kept Card
+	var absorbed []Card
+	mergeCards(cards, func(k Card, a []Card) {
+		kept, absorbed = k, a
+	})
+
+	if kept.Unit != 1 || kept.Level != 1 {
+		t.Errorf("kept = %+v, want unit 1 at level 1", kept)
+	}
+	if len(absorbed) != 2 || absorbed[0].Unit != 3 || absorbed[1].Unit != 4 {
+		t.Errorf("absorbed = %+v, want units 3 and 4", absorbed)
+	}
+}
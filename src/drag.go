@@ -0,0 +1,125 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Drag is a piece being carried by the mouse during the arrange phase,
+// either a unit card from the hand or a piece already on the board.
+type Drag struct {
+	Active    bool
+	Tile      Tile
+	HandIndex int      // index of the dragged card, or -1 for a board piece
+	From      Position // origin square of a board piece
+}
+
+func (drag *Drag) Hides(pos Position) bool {
+	return drag.Active && drag.HandIndex < 0 && drag.From == pos
+}
+
+func (g *Game) DragCard(i int) {
+	card := g.Hand.Cards[i]
+	if card.IsSpell() {
+		return
+	}
+	g.Drag = Drag{
+		Active:    true,
+		Tile:      Tile{Piece: card.Piece, Color: White, Level: card.Level, Unit: card.Unit},
+		HandIndex: i,
+	}
+}
+
+func (g *Game) DragPiece(pos Position) {
+	tile := g.Board.Tiles[pos.Y][pos.X]
+	if tile.Piece == PieceEmpty || tile.Color != White {
+		return
+	}
+	g.Drag = Drag{Active: true, Tile: tile, HandIndex: -1, From: pos}
+}
+
+// CanDrop reports whether the dragged piece may be dropped on the square. A
+// board piece may always go back to where it came from.
+func (g *Game) CanDrop(pos Position) bool {
+	if g.Drag.HandIndex < 0 && pos == g.Drag.From {
+		return true
+	}
+	return g.Board.canPlace(pos.X, pos.Y)
+}
+
+// Drop places the dragged piece on the square, or puts it back when the
+// square is not a legal drop.
+func (g *Game) Drop(pos Position, ok bool) {
+	drag := g.Drag
+	g.Drag = Drag{}
+	if !ok || !g.CanDrop(pos) {
+		return
+	}
+
+	if drag.HandIndex >= 0 {
+		g.Board.Tiles[pos.Y][pos.X] = drag.Tile
+		g.Hand.Remove(drag.HandIndex)
+		g.Events.Emit(Event{Kind: EventPlace, Tile: drag.Tile, Position: pos})
+		return
+	}
+	if pos == drag.From {
+		// picked up and put straight back: a click that inspects the piece
+		g.Overlays.Select(pos)
+		return
+	}
+	g.Board.Tiles[drag.From.Y][drag.From.X] = Tile{Piece: PieceEmpty}
+	g.Board.Tiles[pos.Y][pos.X] = drag.Tile
+	g.Events.Emit(Event{Kind: EventMove, Tile: drag.Tile, Move: Move{From: drag.From, To: pos}})
+}
+
+func (g *Game) UpdateDrag() {
+	if !g.Drag.Active || ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+	mx, my := ebiten.CursorPosition()
+	x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my)
+	g.Drop(Position{X: x, Y: y}, ok)
+}
+
+// DrawDrag marks the legal drop squares, previews the moves the piece would
+// have from the hovered square and draws a ghost of the piece under the
+// cursor.
+func (g *Game) DrawDrag(screen *ebiten.Image) {
+	if !g.Drag.Active {
+		return
+	}
+	origin := &g.Graphics.Board
+	square := func(pos Position) ebiten.DrawImageOptions {
+		return g.Graphics.Position(float64(origin.ScreenX+pos.X*TileSize), float64(origin.ScreenY+pos.Y*TileSize))
+	}
+
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			pos := Position{X: x, Y: y}
+			if g.CanDrop(pos) {
+				opt := square(pos)
+				opt.ColorScale.ScaleAlpha(0.4)
+				screen.DrawImage(Sprites[SpriteHover], &opt)
+			}
+		}
+	}
+
+	mx, my := ebiten.CursorPosition()
+	if x, y, ok := ScreenToTile(origin, mx, my); ok && g.CanDrop(Position{X: x, Y: y}) {
+		preview := g.Board
+		preview.Rules[White] = g.PlayerRules()
+		if g.Drag.HandIndex < 0 {
+			preview.Tiles[g.Drag.From.Y][g.Drag.From.X] = Tile{Piece: PieceEmpty}
+		}
+		preview.Tiles[y][x] = g.Drag.Tile
+		for _, move := range filterSelfCaptures(&preview, getMoves(&preview, x, y)) {
+			opt := square(move.To)
+			opt.ColorScale.Scale(1, 0.5, 0.5, 0.8)
+			screen.DrawImage(Sprites[SpriteHover], &opt)
+		}
+	}
+
+	opt := g.Graphics.Position(float64(mx-TileSize/2), float64(my-TileSize/2))
+	opt.ColorScale.ScaleAlpha(0.7)
+	screen.DrawImage(Sprites[TileToSprite[White][g.Drag.Tile.Piece]], &opt)
+	g.Graphics.DrawLevelBadge(screen, float64(mx-TileSize/2), float64(my-TileSize/2), g.Drag.Tile.Level)
+}
@@ -1,13 +1,174 @@
 package main
 
 import (
+	"image"
+	"image/color"
+	"strings"
+
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/text"
-	"golang.org/x/image/font/basicfont"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// fontFace is the bundled 7x13 pixel font. Larger text is drawn by scaling it
+// by whole steps so the pixels stay crisp.
+var fontFace = text.NewGoXFace(newPixelFont())
+
+const (
+	TextScaleBody  = 1
+	TextScaleTitle = 2
 )
 
+const fontAscent = 11
+const fontLineHeight = 13
+
+var textColors = map[string]color.Color{
+	"white": color.White,
+	"gray":  color.RGBA{0xa0, 0xa0, 0xa8, 0xff},
+	"gold":  badgeColor,
+	"red":   color.RGBA{0xf0, 0x60, 0x50, 0xff},
+	"green": color.RGBA{0x70, 0xd0, 0x70, 0xff},
+	"blue":  color.RGBA{0x70, 0xa0, 0xf0, 0xff},
+}
+
+type TextStyle struct {
+	Scale int         // TextScaleBody when zero
+	Color color.Color // white when nil
+	Align text.Align
+}
+
+func (style TextStyle) scale() float64 {
+	return float64(max(style.Scale, TextScaleBody))
+}
+
+// Span is a run of text drawn in a single color.
+type Span struct {
+	Text  string
+	Color color.Color // the style's color when nil
+}
+
+// ParseSpans splits markup into colored spans. "{gold}3g{}" draws 3g in gold;
+// an empty or unknown tag goes back to the default color.
+func ParseSpans(markup string) []Span {
+	var spans []Span
+	var current color.Color
+	for len(markup) > 0 {
+		open := strings.IndexByte(markup, '{')
+		end := strings.IndexByte(markup, '}')
+		if open < 0 || end < open {
+			spans = append(spans, Span{Text: markup, Color: current})
+			break
+		}
+		if open > 0 {
+			spans = append(spans, Span{Text: markup[:open], Color: current})
+		}
+		current = textColors[markup[open+1:end]]
+		markup = markup[end+1:]
+	}
+	return spans
+}
+
+// PlainText strips the color tags from markup.
+func PlainText(markup string) string {
+	var b strings.Builder
+	for _, span := range ParseSpans(markup) {
+		b.WriteString(span.Text)
+	}
+	return b.String()
+}
+
+func MeasureText(content string, scale int) float64 {
+	return text.Advance(PlainText(content), fontFace) * float64(max(scale, TextScaleBody))
+}
+
+// DrawText draws a line of markup with its baseline at y.
 func (graphics *Graphics) DrawText(screen *ebiten.Image, content string, x, y float64) {
-	op := graphics.Position(x, y)
-	op.GeoM.Scale(1, 1)
-	text.DrawWithOptions(screen, content, basicfont.Face7x13, &op)
+	graphics.DrawTextStyled(screen, content, x, y, TextStyle{})
+}
+
+// DrawTextStyled draws a line of markup with its baseline at y. The
+// alignment decides whether x is the left edge, center or right edge.
+func (graphics *Graphics) DrawTextStyled(screen *ebiten.Image, content string, x, y float64, style TextStyle) {
+	spans := ParseSpans(content)
+	scale := style.scale()
+	switch style.Align {
+	case text.AlignCenter:
+		x -= MeasureText(content, style.Scale) / 2
+	case text.AlignEnd:
+		x -= MeasureText(content, style.Scale)
+	}
+	graphics.drawSpans(screen, spans, x, y-fontAscent*scale, style)
+}
+
+func (graphics *Graphics) drawSpans(screen *ebiten.Image, spans []Span, x, top float64, style TextStyle) {
+	scale := style.scale()
+	for _, span := range spans {
+		clr := span.Color
+		if clr == nil {
+			clr = style.Color
+		}
+		if clr == nil {
+			clr = color.White
+		}
+		op := &text.DrawOptions{}
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(x, top)
+		op.ColorScale.ScaleWithColor(clr)
+		text.Draw(screen, span.Text, fontFace, op)
+		x += text.Advance(span.Text, fontFace) * scale
+	}
+}
+
+// WrapText breaks markup into lines no wider than width. Words are never
+// split, so a single overlong word gets a line to itself.
+func WrapText(content string, width float64, scale int) [][]Span {
+	var lines [][]Span
+	for _, paragraph := range strings.Split(content, "\n") {
+		var line []Span
+		lineWidth := 0.0
+		for _, span := range ParseSpans(paragraph) {
+			for i, word := range strings.Split(span.Text, " ") {
+				if i > 0 && lineWidth > 0 {
+					word = " " + word
+				}
+				wordWidth := MeasureText(word, scale)
+				if lineWidth > 0 && lineWidth+wordWidth > width {
+					lines = append(lines, line)
+					line, lineWidth = nil, 0
+					word = strings.TrimPrefix(word, " ")
+					wordWidth = MeasureText(word, scale)
+				}
+				line = append(line, Span{Text: word, Color: span.Color})
+				lineWidth += wordWidth
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// DrawTextBox word-wraps markup inside the box and returns the height the
+// text took up. Lines past the bottom of the box are dropped.
+func (graphics *Graphics) DrawTextBox(screen *ebiten.Image, content string, box image.Rectangle, style TextStyle) int {
+	scale := style.scale()
+	lineHeight := fontLineHeight * scale
+	top := float64(box.Min.Y)
+	for _, line := range WrapText(content, float64(box.Dx()), style.Scale) {
+		if top+lineHeight > float64(box.Max.Y) {
+			break
+		}
+		x := float64(box.Min.X)
+		width := 0.0
+		for _, span := range line {
+			width += MeasureText(span.Text, style.Scale)
+		}
+		switch style.Align {
+		case text.AlignCenter:
+			x += (float64(box.Dx()) - width) / 2
+		case text.AlignEnd:
+			x += float64(box.Dx()) - width
+		}
+		graphics.drawSpans(screen, line, x, top, style)
+		top += lineHeight
+	}
+	return int(top) - box.Min.Y
 }
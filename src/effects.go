@@ -0,0 +1,209 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Emitter describes a burst of particles. Angles are in radians, with zero
+// pointing right and -π/2 pointing up the screen.
+type Emitter struct {
+	Count   int
+	Color   color.RGBA
+	Speed   float64 // pixels per second
+	Angle   float64
+	Spread  float64
+	Life    float64 // seconds
+	Gravity float64 // pixels per second squared
+	Size    float32
+}
+
+var (
+	captureSparks = Emitter{Count: 14, Color: color.RGBA{0xff, 0xb0, 0x40, 0xff}, Speed: 60, Spread: 2 * math.Pi, Life: 0.4, Gravity: 120, Size: 1}
+	promotionGlow = Emitter{Count: 16, Color: badgeColor, Speed: 20, Angle: -math.Pi / 2, Spread: math.Pi / 2, Life: 0.8, Gravity: -20, Size: 2}
+	placementDust = Emitter{Count: 8, Color: color.RGBA{0xb0, 0xa8, 0x98, 0xff}, Speed: 25, Angle: -math.Pi / 2, Spread: math.Pi, Life: 0.35, Gravity: 40, Size: 1}
+)
+
+type Particle struct {
+	X, Y    float64
+	VX, VY  float64
+	Age     float64
+	Life    float64
+	Gravity float64
+	Color   color.RGBA
+	Size    float32
+}
+
+// Effects runs the particles and screen effects that game events set off.
+// Everything advances by the elapsed time, so effects last as long at any
+// frame rate.
+type Effects struct {
+	board     *GraphicsBoard
+	particles []Particle
+	time      float64
+
+	shake float64 // pixels, decays towards zero
+
+	flash         color.RGBA
+	flashAge      float64
+	flashDuration float64
+
+	hitStop float64 // seconds left
+	canvas  *ebiten.Image
+}
+
+func NewEffects(board *GraphicsBoard) *Effects {
+	return &Effects{board: board}
+}
+
+func (effects *Effects) HandleEvent(event Event) {
+	x := float64(effects.board.ScreenX + event.Position.X*TileSize + TileSize/2)
+	y := float64(effects.board.ScreenY + event.Position.Y*TileSize + TileSize/2)
+	bottom := y + TileSize/2 - 2
+
+	switch event.Kind {
+	case EventPlace:
+		effects.Emit(placementDust, x, bottom)
+	case EventRemove:
+		effects.Emit(placementDust, x, bottom)
+		effects.Shake(ShakeRemove)
+	case EventCapture:
+		effects.Emit(captureSparks, x, y)
+		effects.Shake(ShakeCapture)
+		effects.HitStop(HitStopCapture)
+	case EventPromotion:
+		effects.Emit(promotionGlow, x, y)
+		effects.Flash(badgeColor, FlashDuration)
+	case EventBattleEnd:
+		if event.Won {
+			effects.Flash(color.RGBA{0xff, 0xff, 0xff, 0xff}, FlashDuration)
+		} else {
+			effects.Flash(color.RGBA{0xc0, 0x20, 0x20, 0xff}, FlashDuration)
+		}
+	}
+}
+
+func (effects *Effects) Emit(emitter Emitter, x, y float64) {
+	for range emitter.Count {
+		angle := emitter.Angle + (rand.Float64()-0.5)*emitter.Spread
+		speed := emitter.Speed * (0.5 + rand.Float64()*0.5)
+		effects.particles = append(effects.particles, Particle{
+			X:       x,
+			Y:       y,
+			VX:      math.Cos(angle) * speed,
+			VY:      math.Sin(angle) * speed,
+			Life:    emitter.Life * (0.7 + rand.Float64()*0.3),
+			Gravity: emitter.Gravity,
+			Color:   emitter.Color,
+			Size:    emitter.Size,
+		})
+	}
+}
+
+// Shake jolts the screen by up to the given number of pixels. Shakes do not
+// stack past the strongest one.
+func (effects *Effects) Shake(intensity float64) {
+	effects.shake = max(effects.shake, intensity)
+}
+
+func (effects *Effects) Flash(clr color.RGBA, duration float64) {
+	effects.flash = clr
+	effects.flashAge = 0
+	effects.flashDuration = duration
+}
+
+// HitStop holds the battle still for a moment so a hit lands with weight.
+func (effects *Effects) HitStop(duration float64) {
+	effects.hitStop = max(effects.hitStop, duration)
+}
+
+func (effects *Effects) Stopped() bool {
+	return effects.hitStop > 0
+}
+
+func (effects *Effects) Update(dt float64) {
+	effects.time += dt
+	effects.hitStop = max(effects.hitStop-dt, 0)
+	effects.shake *= math.Exp(-ShakeDecay * dt)
+	if effects.shake < 0.1 {
+		effects.shake = 0
+	}
+	effects.flashAge += dt
+
+	alive := effects.particles[:0]
+	for _, particle := range effects.particles {
+		particle.Age += dt
+		if particle.Age >= particle.Life {
+			continue
+		}
+		particle.VY += particle.Gravity * dt
+		particle.X += particle.VX * dt
+		particle.Y += particle.VY * dt
+		alive = append(alive, particle)
+	}
+	effects.particles = alive
+}
+
+// Clear drops the effects tied to the board. A shake or flash covers the
+// whole screen, so it is left to run out across a change of board.
+func (effects *Effects) Clear() {
+	effects.particles = nil
+	effects.hitStop = 0
+}
+
+// ShakeOffset is how far the screen is displaced this frame, in whole
+// pixels to keep the art crisp.
+func (effects *Effects) ShakeOffset() (float64, float64) {
+	if effects.shake == 0 {
+		return 0, 0
+	}
+	phase := effects.time * ShakeFrequency * 2 * math.Pi
+	return math.Round(effects.shake * math.Sin(phase)), math.Round(effects.shake * math.Cos(phase*1.3))
+}
+
+// DrawShaken draws the scenes displaced by the current shake.
+func (effects *Effects) DrawShaken(screen *ebiten.Image, draw func(screen *ebiten.Image)) {
+	dx, dy := effects.ShakeOffset()
+	if dx == 0 && dy == 0 {
+		draw(screen)
+		return
+	}
+	if effects.canvas == nil || effects.canvas.Bounds() != screen.Bounds() {
+		effects.canvas = ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+	}
+	effects.canvas.Clear()
+	draw(effects.canvas)
+	opt := ebiten.DrawImageOptions{}
+	opt.GeoM.Translate(dx, dy)
+	screen.DrawImage(effects.canvas, &opt)
+}
+
+func (effects *Effects) DrawParticles(screen *ebiten.Image) {
+	for _, particle := range effects.particles {
+		clr := particle.Color
+		fade := 1 - particle.Age/particle.Life
+		clr.R = uint8(float64(clr.R) * fade)
+		clr.G = uint8(float64(clr.G) * fade)
+		clr.B = uint8(float64(clr.B) * fade)
+		clr.A = uint8(float64(clr.A) * fade)
+		vector.FillRect(screen, float32(math.Round(particle.X)), float32(math.Round(particle.Y)), particle.Size, particle.Size, clr, false)
+	}
+}
+
+func (effects *Effects) DrawFlash(screen *ebiten.Image) {
+	if effects.flashAge >= effects.flashDuration {
+		return
+	}
+	clr := effects.flash
+	alpha := FlashAlpha * (1 - effects.flashAge/effects.flashDuration)
+	clr.R = uint8(float64(clr.R) * alpha)
+	clr.G = uint8(float64(clr.G) * alpha)
+	clr.B = uint8(float64(clr.B) * alpha)
+	clr.A = uint8(255 * alpha)
+	bounds := screen.Bounds()
+	vector.FillRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), clr, false)
+}
@@ -0,0 +1,62 @@
+package main
+
+type EventKind int
+
+const (
+	EventMove EventKind = iota
+	EventCapture
+	EventPromotion
+	EventPlace
+	EventRemove
+	EventSpell
+	EventBattleStart
+	EventBattleEnd
+)
+
+type Event struct {
+	Kind     EventKind
+	Move     Move
+	Tile     Tile
+	Position Position
+	Won      bool
+}
+
+// EventBus decouples the game logic from presentation. Logic emits events
+// while it updates, and listeners such as animations receive them when the
+// queue is flushed at the end of the frame.
+type EventBus struct {
+	listeners []func(Event)
+	queue     []Event
+}
+
+func (bus *EventBus) Subscribe(listener func(Event)) {
+	bus.listeners = append(bus.listeners, listener)
+}
+
+func (bus *EventBus) Emit(event Event) {
+	bus.queue = append(bus.queue, event)
+}
+
+func (bus *EventBus) Flush() {
+	for len(bus.queue) > 0 {
+		event := bus.queue[0]
+		bus.queue = bus.queue[1:]
+		for _, listener := range bus.listeners {
+			listener(event)
+		}
+	}
+}
+
+// PlayMove applies a move to the game board and emits the events that
+// describe it.
+func (g *Game) PlayMove(move Move) MoveResult {
+	result := ApplyMove(&g.Board, move)
+	if result.Captured.Piece != PieceEmpty {
+		g.Events.Emit(Event{Kind: EventCapture, Move: move, Tile: result.Captured, Position: move.To})
+	}
+	g.Events.Emit(Event{Kind: EventMove, Move: move, Tile: result.Mover, Position: move.To})
+	if result.Promoted {
+		g.Events.Emit(Event{Kind: EventPromotion, Move: move, Tile: g.Board.Tiles[move.To.Y][move.To.X], Position: move.To})
+	}
+	return result
+}
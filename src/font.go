@@ -0,0 +1,134 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"slices"
+
+	"golang.org/x/image/font/basicfont"
+)
+
+// extraGlyphs are drawn in the style of basicfont.Face7x13 to cover the
+// letters the bundled string tables use beyond ASCII. Rows 0-10 sit above the
+// baseline.
+var extraGlyphs = []struct {
+	Rune rune
+	Rows [13]string
+}{
+	{'Å', [13]string{
+		"..##..",
+		".#..#.",
+		"..##..",
+		".#..#.",
+		"#....#",
+		"#....#",
+		"######",
+		"#....#",
+		"#....#",
+		"#....#",
+		"#....#",
+		"......",
+		"......",
+	}},
+	{'Æ', [13]string{
+		"......",
+		"......",
+		".#####",
+		"#..#..",
+		"#..#..",
+		"#..#..",
+		"#####.",
+		"#..#..",
+		"#..#..",
+		"#..#..",
+		"#..###",
+		"......",
+		"......",
+	}},
+	{'Ø', [13]string{
+		"......",
+		"......",
+		".####.",
+		"#...##",
+		"#...##",
+		"#..#.#",
+		"#..#.#",
+		"#.#..#",
+		"#.#..#",
+		"##...#",
+		".####.",
+		"......",
+		"......",
+	}},
+	{'å', [13]string{
+		"......",
+		"..##..",
+		".#..#.",
+		"..##..",
+		"......",
+		".####.",
+		".....#",
+		".#####",
+		"#....#",
+		"#...##",
+		".###.#",
+		"......",
+		"......",
+	}},
+	{'æ', [13]string{
+		"......",
+		"......",
+		"......",
+		"......",
+		"......",
+		".##.#.",
+		"...#.#",
+		".#####",
+		"#..#..",
+		"#..#.#",
+		".##.#.",
+		"......",
+		"......",
+	}},
+	{'ø', [13]string{
+		"......",
+		"......",
+		"......",
+		"......",
+		"......",
+		".####.",
+		"#...##",
+		"#..#.#",
+		"#.#..#",
+		"##...#",
+		".####.",
+		"......",
+		"......",
+	}},
+}
+
+// newPixelFont returns basicfont.Face7x13 extended with extraGlyphs.
+func newPixelFont() *basicfont.Face {
+	face := *basicfont.Face7x13
+	mask := face.Mask.(*image.Alpha)
+	height := face.Ascent + face.Descent
+	count := mask.Bounds().Dy() / height
+
+	extended := image.NewAlpha(image.Rect(0, 0, mask.Bounds().Dx(), (count+len(extraGlyphs))*height))
+	copy(extended.Pix, mask.Pix)
+	face.Mask = extended
+	face.Ranges = slices.Clone(face.Ranges)
+
+	for i, glyph := range extraGlyphs {
+		offset := count + i
+		for y, row := range glyph.Rows {
+			for x, pixel := range row {
+				if pixel == '#' {
+					extended.SetAlpha(x, offset*height+y, color.Alpha{A: 0xff})
+				}
+			}
+		}
+		face.Ranges = append(face.Ranges, basicfont.Range{Low: glyph.Rune, High: glyph.Rune + 1, Offset: offset})
+	}
+	return &face
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type ForecastResult struct {
+	Battles    int
+	Wins       int
+	Losses     int
+	TotalTurns int
+	Captured   map[string]int // white pieces by name, counted once per battle
+}
+
+// Forecast runs simulated battles of the current arrangement in background
+// goroutines. The result is guarded by mu since workers write to it while
+// the game draws it. Workers only record battles of the current generation,
+// so a run that was cancelled cannot count against the next board.
+type Forecast struct {
+	Enabled bool
+
+	mu         sync.Mutex
+	result     ForecastResult
+	source     Board
+	cancel     context.CancelFunc
+	generation int
+}
+
+func (g *Game) UpdateForecast() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		g.Forecast.Enabled = !g.Forecast.Enabled
+		if !g.Forecast.Enabled {
+			g.Forecast.Stop()
+		}
+	}
+	if !g.Forecast.Enabled {
+		return
+	}
+
+	board := g.Board
+	board.Rules[White] = g.PlayerRules()
+	if g.Forecast.cancel == nil || board != g.Forecast.source {
+		g.Forecast.Start(board, g.Objective, g.Units)
+	}
+}
+
+func (forecast *Forecast) Start(board Board, objective Objective, units map[UnitID]*Unit) {
+	forecast.Stop()
+
+	names := map[UnitID]string{}
+	for id, unit := range units {
+		names[id] = unit.Name
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	forecast.cancel = cancel
+	forecast.source = board
+	forecast.mu.Lock()
+	forecast.generation += 1
+	generation := forecast.generation
+	forecast.result = ForecastResult{Captured: map[string]int{}}
+	forecast.mu.Unlock()
+
+	jobs := make(chan struct{}, ForecastBattles)
+	for range ForecastBattles {
+		jobs <- struct{}{}
+	}
+	close(jobs)
+
+	for range runtime.NumCPU() {
+		go func() {
+			for range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				status, turns, captured := simulateBattle(ctx, board, objective)
+				if !forecast.record(generation, status, turns, captured, names) {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func (forecast *Forecast) Stop() {
+	if forecast.cancel != nil {
+		forecast.cancel()
+		forecast.cancel = nil
+	}
+	forecast.mu.Lock()
+	forecast.generation += 1
+	forecast.mu.Unlock()
+}
+
+func (forecast *Forecast) Result() ForecastResult {
+	forecast.mu.Lock()
+	defer forecast.mu.Unlock()
+	result := forecast.result
+	result.Captured = map[string]int{}
+	for name, count := range forecast.result.Captured {
+		result.Captured[name] = count
+	}
+	return result
+}
+
+// record adds a finished battle to the result. It reports false once the
+// battle's generation has been stopped or replaced.
+func (forecast *Forecast) record(generation int, status ObjectiveStatus, turns int, captured []Tile, names map[UnitID]string) bool {
+	forecast.mu.Lock()
+	defer forecast.mu.Unlock()
+	if forecast.generation != generation {
+		return false
+	}
+
+	result := &forecast.result
+	result.Battles += 1
+	result.TotalTurns += turns
+	if status == ObjectiveWon {
+		result.Wins += 1
+	} else {
+		result.Losses += 1
+	}
+
+	seen := map[string]bool{}
+	for _, tile := range captured {
+		name := PieceName(tile.Piece)
+		if unit, ok := names[tile.Unit]; ok {
+			name = unit
+		}
+		if !seen[name] {
+			seen[name] = true
+			result.Captured[name] += 1
+		}
+	}
+	return true
+}
+
+// simulateBattle plays a battle to the end with a shallow search. The random
+// tie-breaks in negamax make every run a little different.
+func simulateBattle(ctx context.Context, board Board, objective Objective) (ObjectiveStatus, int, []Tile) {
+	captured := []Tile{}
+	for ctx.Err() == nil {
+		move, ok := ComputeMove(&board, ForecastDepth)
+		if ok {
+			target := board.Tiles[move.To.Y][move.To.X]
+			ApplyMove(&board, move)
+			if target.Piece != PieceEmpty && target.Color == White {
+				captured = append(captured, target)
+			}
+		} else {
+			board.PassTurn()
+		}
+
+		if status := objective.Evaluate(&board); status != ObjectivePending {
+			return status, board.FullTurns(), captured
+		}
+	}
+	return ObjectivePending, board.FullTurns(), captured
+}
+
+func (g *Game) DrawForecast(screen *ebiten.Image) {
+	if !g.Forecast.Enabled {
+		return
+	}
+	result := g.Forecast.Result()
+
+	lines := []string{T("forecast.battles", "done", result.Battles, "total", ForecastBattles)}
+	if result.Battles > 0 {
+		percent := func(n int) int { return n * 100 / result.Battles }
+		lines = append(lines,
+			T("forecast.win", "percent", percent(result.Wins)),
+			T("forecast.loss", "percent", percent(result.Losses)),
+			T("forecast.turns", "turns", fmt.Sprintf("%.1f", float64(result.TotalTurns)/float64(result.Battles))),
+		)
+
+		names := []string{}
+		for name := range result.Captured {
+			names = append(names, name)
+		}
+		slices.SortFunc(names, func(a, b string) int {
+			return result.Captured[b] - result.Captured[a]
+		})
+		for _, name := range names[:min(len(names), 2)] {
+			lines = append(lines, T("forecast.captured", "name", name, "percent", percent(result.Captured[name])))
+		}
+	}
+
+	for i, line := range lines {
+		g.Graphics.DrawText(screen, line, 4, float64(TileSize*7+i*12))
+	}
+}
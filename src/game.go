@@ -2,43 +2,78 @@ package main
 
 import "time"
 
-type State int
-
-const (
-	StateArrange State = iota
-	StatePlay
-	StateShop
-)
-
 type Game struct {
 	Board      Board
-	Shop	   Shop
+	Shop       Shop
 	Graphics   Graphics
 	Deck       Deck
 	Hand       Hand
-	State      State
+	Editor     Editor
+	Objective  Objective
+	Forecast   *Forecast
+	Optimizer  *Optimizer
+	Events     *EventBus
+	Animator   *Animator
+	Overlays   *Overlays
+	Audio      *AudioManager
+	Effects    *Effects
+	Scenes     *SceneStack
 	MatchIndex int
+	Seed       int64
+	Gold       int
+	Relics     []Relic
+	Settings   Settings
+	Stats      RunStats
+	RunActive  bool // a run is in progress and can be continued
+
+	Units      map[UnitID]*Unit
+	NextUnitID UnitID
+	Permadeath bool // fixed for the run from Settings.Permadeath
+
+	Intent     Intent
+	Drag       Drag
+	ShowIntent bool
+
+	BattleCaptures int
+	Deployed       []UnitID
+	Casualties     []UnitID
 
 	PrevComputerTime time.Time
+	Speed            BattleSpeed
+	Paused           bool
+	History          []Board
 	Debug            bool
+	Quitting         bool
+
+	// where the editor saves matches and runs look for them before the
+	// embedded ones, empty when there is nowhere to save
+	MatchDir string
 }
 
-func NewGame() Game {
-	game := Game{
+func NewGame() *Game {
+	game := &Game{
 		Graphics: Graphics{
 			Board: GraphicsBoard{
 				ScreenX: LayoutWidth/2 - TileSize*BoardWidth/2,
 				ScreenY: TileSize,
 			},
 		},
-		State: StateArrange,
-		Deck:  Deck{DrawCount: 3},
-	}
-	game.Deck.Cards = append(game.Deck.Cards, Card{Piece: PiecePawn})
+		Settings: DefaultSettings(),
+		Units:    map[UnitID]*Unit{},
 
-	game.Shop.items = append(game.Shop.items, Card{Piece: PieceKnight})
-
-	game.AddCardsFromDeckToHand()
-	game.StartMatch(game.MatchIndex)
+		Forecast:  &Forecast{},
+		Optimizer: &Optimizer{},
+		Events:    &EventBus{},
+		Animator:  &Animator{},
+		Overlays:  &Overlays{},
+		Scenes:    &SceneStack{},
+	}
+	game.Events.Subscribe(game.Animator.HandleEvent)
+	game.Audio = NewAudioManager(&game.Settings)
+	game.Effects = NewEffects(&game.Graphics.Board)
+	game.Events.Subscribe(game.Effects.HandleEvent)
+	game.Events.Subscribe(game.Overlays.HandleEvent)
+	game.Events.Subscribe(game.Audio.HandleEvent)
+	game.Scenes.Push(game, &MenuScene{})
 	return game
 }
@@ -1,6 +1,11 @@
 package main
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
 
 type Graphics struct {
 	Board GraphicsBoard
@@ -16,3 +21,13 @@ func (graphics *Graphics) Position(x, y float64) ebiten.DrawImageOptions {
 	op.GeoM.Translate(x, y)
 	return op
 }
+
+var badgeColor = color.RGBA{0xf0, 0xc0, 0x40, 0xff}
+
+// DrawLevelBadge draws one pip per level in the top-left corner of a tile.
+func (graphics *Graphics) DrawLevelBadge(screen *ebiten.Image, x, y float64, level int) {
+	for i := range level {
+		px := float32(x) + 1 + float32(i)*3
+		vector.FillRect(screen, px, float32(y)+1, 2, 2, badgeColor, false)
+	}
+}
@@ -1,61 +1,139 @@
 package main
 
 import (
-	"math"
 	"math/rand"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 type Hand struct {
 	Cards []Card
 	Limit int
 
-	SelectIndex int
+	SelectIndex int        // -1 when no card is selected
+	Targets     []Position // picked so far for the selected spell
 }
 
-func (g *Game) UpdateHand() {
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
+func (hand *Hand) Selected() (Card, bool) {
+	if hand.SelectIndex < 0 || hand.SelectIndex >= len(hand.Cards) {
+		return Card{}, false
+	}
+	return hand.Cards[hand.SelectIndex], true
+}
 
-		for i := range g.Hand.Cards {
-			x, y := GetPositionForCard(i)
-			half := float64(TileSize / 2)
-			dx := math.Abs(x + half - float64(mx))
-			dy := math.Abs(y + half - float64(my))
+func (hand *Hand) Remove(i int) {
+	hand.Cards = slices.Delete(hand.Cards, i, i+1)
+	hand.SelectIndex = -1
+	hand.Targets = nil
+}
 
-			if dx < half && dy < half {
-				g.Hand.SelectIndex = i
+func (hand *Hand) Select(i int) {
+	hand.SelectIndex = i
+	hand.Targets = nil
+}
+
+// NewHandList creates the hand column next to the board. SyncHandList keeps
+// its slots in step with the cards in the hand; unit cards in a draggable
+// hand can be dragged onto the board.
+func (g *Game) NewHandList() *ScrollList {
+	return &ScrollList{
+		Base:       Base{Layout: Layout{X: TileSize*3 + TileSize*BoardWidth*2, Y: TileSize, W: TileSize, H: TileSize * 12}},
+		ItemHeight: TileSize,
+	}
+}
+
+func (g *Game) SyncHandList(list *ScrollList, draggable bool) {
+	for len(list.Items) < len(g.Hand.Cards) {
+		i := len(list.Items)
+		slot := &CardSlot{
+			Base: Base{Layout: Layout{W: TileSize, H: TileSize}, OnClick: func() { g.Hand.Select(i) }},
+		}
+		if draggable {
+			slot.OnPress = func() {
+				g.Hand.Select(i)
+				g.DragCard(i)
 			}
 		}
+		list.Items = append(list.Items, slot)
 	}
-}
+	list.Items = list.Items[:len(g.Hand.Cards)]
 
-func (g *Game) DrawHand(screen *ebiten.Image) {
 	for i, card := range g.Hand.Cards {
-		x, y := GetPositionForCard(i)
-		opt := g.Graphics.Position(x, y)
+		slot := list.Items[i].(*CardSlot)
+		slot.Card = card
+		slot.Selected = i == g.Hand.SelectIndex
+		slot.Tooltip = card.Name()
+		if !card.IsSpell() {
+			slot.Tooltip = g.PieceSummary(Tile{Piece: card.Piece, Color: White, Level: card.Level, Unit: card.Unit})
+		}
+	}
+}
 
-		spriteId := TileToSprite[White][card.Piece]
-		screen.DrawImage(Sprites[spriteId], &opt)
+// DrawSpellTargets marks the squares picked so far for the selected spell.
+func (g *Game) DrawSpellTargets(screen *ebiten.Image) {
+	for _, target := range g.Hand.Targets {
+		x := float64(g.Graphics.Board.ScreenX + target.X*TileSize)
+		y := float64(g.Graphics.Board.ScreenY + target.Y*TileSize)
+		opt := g.Graphics.Position(x, y)
+		screen.DrawImage(Sprites[SpriteHover], &opt)
+	}
+}
 
-		if i == g.Hand.SelectIndex {
-			screen.DrawImage(Sprites[SpriteHover], &opt)
-		}
+func (g *Game) DrawCard(screen *ebiten.Image, card Card, x, y float64) {
+	opt := g.Graphics.Position(x, y)
+	if card.IsSpell() {
+		screen.DrawImage(Sprites[SpriteButtonSmall], &opt)
+		screen.DrawImage(Sprites[SpellToSprite[card.Spell]], &opt)
+	} else {
+		frame := ClipFrame(TileToSprite[White][card.Piece], ClipIdle, g.Animator.Time)
+		g.Graphics.DrawFrame(screen, frame, opt, White)
 	}
+	g.Graphics.DrawLevelBadge(screen, x, y, card.Level)
 }
 
-func GetPositionForCard(i int) (float64, float64) {
-	x := float64(TileSize*3 + TileSize*BoardWidth*2)
-	y := float64(i+1) * TileSize
-	return x, y
+// TargetSpell records a target for the selected spell card and casts it once
+// every target has been picked. Targets that break the card's rules are
+// ignored.
+func (g *Game) TargetSpell(pos Position) {
+	card, ok := g.Hand.Selected()
+	if !ok || !card.IsSpell() {
+		return
+	}
+	effect := cardEffects[card.Spell]
+	rules := effect.Targets()
+
+	if slices.Contains(g.Hand.Targets, pos) || !rules[len(g.Hand.Targets)].Allows(&g.Board, pos) {
+		return
+	}
+	g.Hand.Targets = append(g.Hand.Targets, pos)
+
+	if len(g.Hand.Targets) == len(rules) {
+		effect.Apply(&g.Board, g.Hand.Targets, card.Level)
+		for _, target := range g.Hand.Targets {
+			g.Events.Emit(Event{Kind: EventSpell, Tile: g.Board.Tiles[target.Y][target.X], Position: target})
+		}
+		g.Hand.Remove(g.Hand.SelectIndex)
+	}
 }
 
+// AddCardsFromDeckToHand draws distinct deck cards, skipping units that are
+// already in the hand, since a unit can only be deployed once.
 func (g *Game) AddCardsFromDeckToHand() {
-	for range g.Deck.DrawCount {
-		idx := rand.Intn(len(g.Deck.Cards))
+	drawn := 0
+	for _, idx := range rand.Perm(len(g.Deck.Cards)) {
+		if drawn >= g.Deck.DrawCount {
+			break
+		}
 		card := g.Deck.Cards[idx]
+		inHand := slices.ContainsFunc(g.Hand.Cards, func(held Card) bool {
+			return card.Unit != 0 && held.Unit == card.Unit
+		})
+		if inHand {
+			continue
+		}
 		g.Hand.Cards = append(g.Hand.Cards, card)
+		drawn += 1
 	}
+	g.Hand.SelectIndex = -1
 }
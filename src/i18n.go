@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// message is one translated string. Strings that depend on a count list a
+// form per plural category, such as {"one": "...", "other": "..."}.
+type message map[string]string
+
+func (m *message) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		*m = message{"other": plain}
+		return nil
+	}
+	forms := map[string]string{}
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return err
+	}
+	*m = forms
+	return nil
+}
+
+// pluralRules picks the plural category of a count for each language.
+// Languages without a rule use pluralOneOther.
+var pluralRules = map[string]func(n int) string{
+	"en": pluralOneOther,
+	"nb": pluralOneOther,
+}
+
+func pluralOneOther(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// Translator resolves string keys in the active language, falling back to
+// English. Keys the active language lacks are remembered in Missing so they
+// can be reported while debugging.
+type Translator struct {
+	mu        sync.Mutex
+	Languages map[string]map[string]message
+	Active    string
+	Missing   []string
+}
+
+const FallbackLanguage = "en"
+
+var translator = &Translator{Languages: map[string]map[string]message{}, Active: FallbackLanguage}
+
+// LoadLanguages reads every <code>.json string table in the directory.
+func (translator *Translator) LoadLanguages(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		table := map[string]message{}
+		if err := json.Unmarshal(data, &table); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		translator.Languages[strings.TrimSuffix(entry.Name(), ".json")] = table
+	}
+	if _, ok := translator.Languages[FallbackLanguage]; !ok {
+		return fmt.Errorf("missing %s.json in %s", FallbackLanguage, dir)
+	}
+	return nil
+}
+
+func (translator *Translator) MissingKeys() []string {
+	translator.mu.Lock()
+	defer translator.mu.Unlock()
+	return slices.Clone(translator.Missing)
+}
+
+// Codes lists the loaded languages with the fallback language first.
+func (translator *Translator) Codes() []string {
+	codes := []string{}
+	for code := range translator.Languages {
+		codes = append(codes, code)
+	}
+	slices.SortFunc(codes, func(a, b string) int {
+		switch {
+		case a == FallbackLanguage:
+			return -1
+		case b == FallbackLanguage:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return codes
+}
+
+func (translator *Translator) SetLanguage(code string) {
+	translator.mu.Lock()
+	defer translator.mu.Unlock()
+	if _, ok := translator.Languages[code]; ok {
+		translator.Active = code
+		translator.Missing = nil
+	}
+}
+
+// Translate looks up key and fills in its {{name}} placeholders from pairs of
+// name and value arguments. An int "count" argument selects the plural form.
+func (translator *Translator) Translate(key string, args ...any) string {
+	translator.mu.Lock()
+	defer translator.mu.Unlock()
+
+	code := translator.Active
+	msg, ok := translator.Languages[code][key]
+	if !ok {
+		if !slices.Contains(translator.Missing, key) {
+			translator.Missing = append(translator.Missing, key)
+		}
+		code = FallbackLanguage
+		msg, ok = translator.Languages[code][key]
+		if !ok {
+			return key
+		}
+	}
+
+	form := "other"
+	for i := 0; i+1 < len(args); i += 2 {
+		if count, ok := args[i+1].(int); ok && args[i] == "count" {
+			rule, ok := pluralRules[code]
+			if !ok {
+				rule = pluralOneOther
+			}
+			form = rule(count)
+		}
+	}
+	content, ok := msg[form]
+	if !ok {
+		content = msg["other"]
+	}
+
+	for i := 0; i+1 < len(args); i += 2 {
+		placeholder := fmt.Sprintf("{{%v}}", args[i])
+		content = strings.ReplaceAll(content, placeholder, fmt.Sprint(args[i+1]))
+	}
+	return content
+}
+
+// T translates a key in the active language. See Translator.Translate.
+func T(key string, args ...any) string {
+	return translator.Translate(key, args...)
+}
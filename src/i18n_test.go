@@ -0,0 +1,118 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func testTranslator(active string) *Translator {
+	return &Translator{
+		Languages: map[string]map[string]message{
+			"en": {
+				"greeting": {"other": "Hello, {{name}}!"},
+				"pieces":   {"one": "{{count}} piece", "other": "{{count}} pieces"},
+				"plain":    {"other": "Plain"},
+				"only_en":  {"other": "English only"},
+			},
+			"nb": {
+				"greeting": {"other": "Hei, {{name}}!"},
+				"pieces":   {"one": "{{count}} brikke", "other": "{{count}} brikker"},
+				"plain":    {"other": "Enkel"},
+			},
+		},
+		Active: active,
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name   string
+		active string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"substitution", "en", "greeting", []any{"name", "Ada"}, "Hello, Ada!"},
+		{"unfilled placeholder", "en", "greeting", nil, "Hello, {{name}}!"},
+		{"plural one", "en", "pieces", []any{"count", 1}, "1 piece"},
+		{"plural other", "en", "pieces", []any{"count", 3}, "3 pieces"},
+		{"plural zero", "en", "pieces", []any{"count", 0}, "0 pieces"},
+		{"plural without count", "en", "pieces", nil, "{{count}} pieces"},
+		{"plural in other language", "nb", "pieces", []any{"count", 1}, "1 brikke"},
+		{"plain with count", "nb", "plain", []any{"count", 1}, "Enkel"},
+		{"fallback to English", "nb", "only_en", nil, "English only"},
+		{"missing everywhere", "nb", "nowhere", nil, "nowhere"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			translator := testTranslator(test.active)
+			if got := translator.Translate(test.key, test.args...); got != test.want {
+				t.Errorf("Translate(%q) = %q, want %q", test.key, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTranslateReportsMissingKeys(t *testing.T) {
+	translator := testTranslator("nb")
+	translator.Translate("plain")
+	translator.Translate("only_en")
+	translator.Translate("nowhere")
+	translator.Translate("only_en")
+
+	if got, want := translator.MissingKeys(), []string{"only_en", "nowhere"}; !slices.Equal(got, want) {
+		t.Errorf("MissingKeys() = %v, want %v", got, want)
+	}
+
+	translator.SetLanguage("en")
+	if got := translator.MissingKeys(); len(got) != 0 {
+		t.Errorf("MissingKeys() after SetLanguage = %v, want none", got)
+	}
+}
+
+func TestLoadLanguages(t *testing.T) {
+	tests := []struct {
+		name  string
+		fsys  fstest.MapFS
+		codes []string
+		valid bool
+	}{
+		{
+			name: "languages",
+			fsys: fstest.MapFS{
+				"lang/en.json":   {Data: []byte(`{"plain": "Plain", "pieces": {"one": "piece", "other": "pieces"}}`)},
+				"lang/nb.json":   {Data: []byte(`{"plain": "Enkel"}`)},
+				"lang/notes.txt": {Data: []byte(`not a language`)},
+			},
+			codes: []string{"en", "nb"},
+			valid: true,
+		},
+		{
+			name: "missing English",
+			fsys: fstest.MapFS{
+				"lang/nb.json": {Data: []byte(`{"plain": "Enkel"}`)},
+			},
+		},
+		{
+			name: "malformed table",
+			fsys: fstest.MapFS{
+				"lang/en.json": {Data: []byte(`{"plain": 1}`)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			translator := &Translator{Languages: map[string]map[string]message{}, Active: FallbackLanguage}
+			err := translator.LoadLanguages(test.fsys, "lang")
+			if (err == nil) != test.valid {
+				t.Fatalf("LoadLanguages() = %v, want valid %t", err, test.valid)
+			}
+			if test.valid && !slices.Equal(translator.Codes(), test.codes) {
+				t.Errorf("Codes() = %v, want %v", translator.Codes(), test.codes)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var diagramLight = color.RGBA{0x58, 0x50, 0x68, 0xff}
+var diagramDark = color.RGBA{0x40, 0x3a, 0x50, 0xff}
+var diagramMoveColor = color.RGBA{0x70, 0xd0, 0x70, 0xff}
+var diagramCaptureColor = color.RGBA{0xf0, 0x60, 0x50, 0xff}
+
+const diagramCell = 8
+
+// Inspected returns the piece the inspector describes: the selected unit
+// card in the hand, or else the selected board piece of either color.
+func (g *Game) Inspected() (Tile, bool) {
+	if card, ok := g.Hand.Selected(); ok && !card.IsSpell() {
+		return Tile{Piece: card.Piece, Color: White, Level: card.Level, Unit: card.Unit}, true
+	}
+	selected := g.Overlays.Selected
+	if g.Overlays.HasSelected {
+		if tile := g.Board.Tiles[selected.Y][selected.X]; tile.Piece != PieceEmpty {
+			return tile, true
+		}
+	}
+	return Tile{}, false
+}
+
+// rulesFor returns the rules the piece moves by. The player's pieces get
+// their synergies and relics; enemies move by the board's rules.
+func (g *Game) rulesFor(color Color) Rules {
+	if color == White {
+		return g.PlayerRules()
+	}
+	return g.Board.Rules[color]
+}
+
+// PieceSummary is the short description shown when hovering a piece or a
+// card.
+func (g *Game) PieceSummary(tile Tile) string {
+	lines := []string{"{gold}" + PieceName(tile.Piece) + "{}"}
+	if tile.Color == Black {
+		lines[0] += " " + T("inspector.enemy")
+	}
+	lines = append(lines, g.pieceDetails(tile)...)
+	return strings.Join(lines, "\n")
+}
+
+// pieceDetails lists the value, level, traits and unit of a piece, one
+// per line.
+func (g *Game) pieceDetails(tile Tile) []string {
+	value := pieceScores[tile.Piece] + g.rulesFor(tile.Color).PieceValueBonus[tile.Piece]
+	lines := []string{
+		T("inspector.value", "value", fmt.Sprintf("%g", value)),
+		T("inspector.level", "level", tile.Level),
+	}
+	traits := []string{}
+	for _, trait := range pieceTraits[tile.Piece] {
+		traits = append(traits, T(traitNames[trait]))
+	}
+	if len(traits) > 0 {
+		lines = append(lines, T("inspector.traits", "traits", strings.Join(traits, ", ")))
+	}
+	if tile.King {
+		lines = append(lines, "{red}"+T("inspector.royal")+"{}")
+	}
+	if tile.Frozen > 0 {
+		lines = append(lines, "{blue}"+T("inspector.frozen", "count", tile.Frozen)+"{}")
+	}
+	if unit, ok := g.Units[tile.Unit]; ok && tile.Color == White {
+		lines = append(lines, unit.String())
+	}
+	return lines
+}
+
+// MovementDiagram runs the move generator for the piece on an otherwise
+// empty board. moves are the squares it can step to; captures are the
+// squares it can only take an enemy on, found by placing a lone enemy there.
+func (g *Game) MovementDiagram(tile Tile) (origin Position, moves, captures [BoardHeight][BoardWidth]bool) {
+	origin = Position{X: BoardWidth/2 - 1, Y: BoardHeight / 2}
+	if tile.Color == Black {
+		origin = Position{X: BoardWidth / 2, Y: BoardHeight/2 - 1}
+	}
+	tile.Frozen = 0
+
+	board := Board{}
+	board.Rules[tile.Color] = g.rulesFor(tile.Color)
+	board.Tiles[origin.Y][origin.X] = tile
+	for _, move := range getMoves(&board, origin.X, origin.Y) {
+		moves[move.To.Y][move.To.X] = true
+	}
+
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			if moves[y][x] || (Position{X: x, Y: y}) == origin {
+				continue
+			}
+			target := board
+			target.Tiles[y][x] = Tile{Piece: PiecePawn, Color: tile.Color.Opponent()}
+			for _, move := range getMoves(&target, origin.X, origin.Y) {
+				if move.To == (Position{X: x, Y: y}) {
+					captures[y][x] = true
+				}
+			}
+		}
+	}
+	return origin, moves, captures
+}
+
+// InspectorPanel is the side panel describing the inspected piece. It hides
+// itself when nothing is inspected.
+type InspectorPanel struct {
+	Base
+}
+
+func NewInspectorPanel() *InspectorPanel {
+	return &InspectorPanel{Base: Base{Layout: Layout{X: LayoutWidth/2 + TileSize*BoardWidth/2 + 2, Y: TileSize, W: 76, H: 168}}}
+}
+
+func (panel *InspectorPanel) Refresh(g *Game) {
+	_, ok := g.Inspected()
+	panel.Hidden = !ok
+}
+
+func (panel *InspectorPanel) Children() []Widget { return nil }
+
+func (panel *InspectorPanel) Draw(g *Game, screen *ebiten.Image) {
+	tile, ok := g.Inspected()
+	if !ok {
+		return
+	}
+	r := panel.rect
+	vector.FillRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), panelColor, false)
+
+	box := r.Inset(4)
+	box.Min.Y += g.Graphics.DrawTextBox(screen, "{gold}"+PieceName(tile.Piece)+"{}", box, TextStyle{})
+	if tile.Color == Black {
+		box.Min.Y += g.Graphics.DrawTextBox(screen, "{red}"+T("inspector.enemy")+"{}", box, TextStyle{})
+	}
+	diagramTop := r.Max.Y - 4 - diagramCell*BoardHeight
+	details := image.Rect(box.Min.X, box.Min.Y, box.Max.X, diagramTop)
+	g.Graphics.DrawTextBox(screen, strings.Join(g.pieceDetails(tile), "\n"), details, TextStyle{Color: textColors["gray"]})
+
+	origin, moves, captures := g.MovementDiagram(tile)
+	left := float32(r.Min.X + (r.Dx()-diagramCell*BoardWidth)/2)
+	top := float32(diagramTop)
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			cx := left + float32(x*diagramCell)
+			cy := top + float32(y*diagramCell)
+			clr := diagramLight
+			if (x+y)%2 == 0 {
+				clr = diagramDark
+			}
+			vector.FillRect(screen, cx, cy, diagramCell, diagramCell, clr, false)
+			switch {
+			case moves[y][x]:
+				vector.FillRect(screen, cx+2, cy+2, diagramCell-4, diagramCell-4, diagramMoveColor, false)
+			case captures[y][x]:
+				vector.StrokeRect(screen, cx+1.5, cy+1.5, diagramCell-3, diagramCell-3, 1, diagramCaptureColor, false)
+			}
+		}
+	}
+	opt := g.Graphics.Position(0, 0)
+	opt.GeoM.Scale(float64(diagramCell)/TileSize, float64(diagramCell)/TileSize)
+	opt.GeoM.Translate(float64(left)+float64(origin.X*diagramCell), float64(top)+float64(origin.Y*diagramCell))
+	screen.DrawImage(Sprites[TileToSprite[tile.Color][tile.Piece]], &opt)
+}
+
+// UpdateHover counts how long the cursor has rested on the same board
+// square, for the piece tooltip.
+func (g *Game) UpdateHover() {
+	mx, my := ebiten.CursorPosition()
+	x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my)
+	pos := Position{X: x, Y: y}
+	if !ok || pos != g.Overlays.Hovered {
+		g.Overlays.HoverTicks = 0
+	}
+	g.Overlays.Hovered = pos
+	if ok {
+		g.Overlays.HoverTicks += 1
+	}
+}
+
+// DrawPieceTooltip describes the piece under the cursor once it has rested
+// there for TooltipDelay.
+func (g *Game) DrawPieceTooltip(screen *ebiten.Image) {
+	if g.Drag.Active || g.Overlays.HoverTicks < int(TooltipDelay*float64(ebiten.TPS())) {
+		return
+	}
+	pos := g.Overlays.Hovered
+	tile := g.Board.Tiles[pos.Y][pos.X]
+	if tile.Piece == PieceEmpty {
+		return
+	}
+	mx, my := ebiten.CursorPosition()
+	g.DrawTooltip(screen, g.PieceSummary(tile), mx, my)
+}
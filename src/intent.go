@@ -0,0 +1,107 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var intentColor = color.RGBA{0xe0, 0x40, 0x40, 0xc0}
+var threatColor = color.RGBA{0x60, 0x10, 0x10, 0x40}
+
+// Intent is black's next move as predicted by the last search.
+type Intent struct {
+	Move  Move
+	Valid bool
+}
+
+// predictIntent walks the principal variation from the current board and
+// returns the first move black is expected to make after line[0].
+func predictIntent(board Board, line []Move) Intent {
+	for i, move := range line {
+		if i > 0 && board.Color() == Black {
+			return Intent{Move: move, Valid: true}
+		}
+		ApplyMove(&board, move)
+	}
+	return Intent{}
+}
+
+// attackedSquares marks every square a piece of the given color could
+// capture on next turn. Pawns only attack diagonally.
+func (board *Board) attackedSquares(color Color) [BoardHeight][BoardWidth]bool {
+	attacked := [BoardHeight][BoardWidth]bool{}
+	for _, move := range generateMovesForColor(board, color) {
+		if board.Tiles[move.From.Y][move.From.X].Piece == PiecePawn && move.From.X == move.To.X {
+			continue
+		}
+		attacked[move.To.Y][move.To.X] = true
+	}
+
+	direction := -1
+	if color == Black {
+		direction = 1
+	}
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			tile := board.Tiles[y][x]
+			if tile.Piece != PiecePawn || tile.Color != color || tile.Frozen > 0 {
+				continue
+			}
+			for _, dx := range []int{-1, 1} {
+				nx, ny := x+dx, y+direction
+				if nx >= 0 && nx < BoardWidth && ny >= 0 && ny < BoardHeight && !board.isWall(nx, ny) {
+					attacked[ny][nx] = true
+				}
+			}
+		}
+	}
+	return attacked
+}
+
+func (g *Game) UpdateIntent() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		g.ShowIntent = !g.ShowIntent
+	}
+}
+
+func (g *Game) DrawIntent(screen *ebiten.Image) {
+	if !g.ShowIntent {
+		return
+	}
+	originX := float32(g.Graphics.Board.ScreenX)
+	originY := float32(g.Graphics.Board.ScreenY)
+
+	attacked := g.Board.attackedSquares(Black)
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			if attacked[y][x] {
+				px := originX + float32(x*TileSize)
+				py := originY + float32(y*TileSize)
+				vector.FillRect(screen, px, py, TileSize, TileSize, threatColor, false)
+			}
+		}
+	}
+
+	if !g.Intent.Valid {
+		return
+	}
+	half := float32(TileSize) / 2
+	x0 := originX + float32(g.Intent.Move.From.X*TileSize) + half
+	y0 := originY + float32(g.Intent.Move.From.Y*TileSize) + half
+	x1 := originX + float32(g.Intent.Move.To.X*TileSize) + half
+	y1 := originY + float32(g.Intent.Move.To.Y*TileSize) + half
+	vector.StrokeLine(screen, x0, y0, x1, y1, 2, intentColor, true)
+
+	// arrow head
+	angle := math.Atan2(float64(y1-y0), float64(x1-x0))
+	for _, side := range []float64{-1, 1} {
+		a := angle + math.Pi + side*math.Pi/6
+		hx := x1 + float32(math.Cos(a))*5
+		hy := y1 + float32(math.Sin(a))*5
+		vector.StrokeLine(screen, x1, y1, hx, hy, 2, intentColor, true)
+	}
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	_ "image/png"
 	"log"
+	"strings"
+
+	"chess-battles/assets"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -11,22 +15,20 @@ import (
 )
 
 func (g *Game) Update() error {
+	dt := 1 / float64(ebiten.TPS())
+	g.Effects.Update(dt)
+	if !g.Effects.Stopped() {
+		g.Animator.Update(dt)
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
 		g.Debug = !g.Debug
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
-		switch g.State {
-			case StateShop:
-				g.State = StateArrange
-			case StateArrange:
-				g.State = StateShop
-			}
-		
-	}
-	if g.Debug {
+	if g.Debug && g.RunActive {
 		if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-			g.State = StateArrange
+			g.Scenes.Reset(g, &ArrangeScene{})
+			g.StartMatch(g.MatchIndex)
 		}
 		if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
 			g.MatchIndex = g.MatchIndex + 1
@@ -38,30 +40,31 @@ func (g *Game) Update() error {
 		}
 	}
 
-	switch g.State {
-	case StateArrange:
-		g.UpdateStateArrange()
-	case StatePlay:
-		g.UpdateStatePlay()
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) && g.canPause() {
+		g.Scenes.Push(g, &PauseScene{})
+	} else {
+		g.Scenes.Update(g)
+	}
+
+	g.Events.Flush()
+	g.Audio.Update(g.Scenes.Music())
+	if g.Quitting {
+		return ebiten.Termination
 	}
 	return nil
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	if g.State == StateShop {
-		g.DrawShop(screen)
-	} else {
-		g.DrawBoard(screen)
-	}
-
-
-	if g.State == StateArrange {
-		g.DrawHand(screen)
-		g.DrawControl(screen)
-	}
+	g.Effects.DrawShaken(screen, func(screen *ebiten.Image) {
+		g.Scenes.Draw(g, screen)
+	})
+	g.Effects.DrawFlash(screen)
 
 	if g.Debug {
-		ebitenutil.DebugPrint(screen, fmt.Sprintf("State: %d, Match: %d", g.State, g.MatchIndex))
+		ebitenutil.DebugPrint(screen, fmt.Sprintf("Scene: %T, Match: %d, Permadeath: %t", g.Scenes.Top(), g.MatchIndex, g.Permadeath))
+		if missing := translator.MissingKeys(); len(missing) > 0 {
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Missing %s: %s", translator.Active, strings.Join(missing, ", ")), 0, 16)
+		}
 	}
 }
 
@@ -70,12 +73,22 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 }
 
 func main() {
+	matchDir := flag.String("matches", DefaultMatchDir(), "directory the match editor saves to")
+	flag.Parse()
+
 	ebiten.SetWindowSize(640, 480)
-	ebiten.SetWindowTitle("Hello, Chess Battles!")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetTPS(60)
+	if err := LoadSprites(assets.FS); err != nil {
+		log.Fatal(err)
+	}
+	if err := translator.LoadLanguages(assets.FS, LangDirPath); err != nil {
+		log.Fatal(err)
+	}
+	ebiten.SetWindowTitle(T("menu.title"))
 	game := NewGame()
-	if err := ebiten.RunGame(&game); err != nil {
+	game.MatchDir = *matchDir
+	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -1,7 +1,34 @@
 package main
 
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"math/rand"
+	"os"
+	"path"
+
+	"chess-battles/assets"
+)
+
+// StartMatch sets up match i from its authored match file, or else from the
+// built-in boards and the generator.
 func (g *Game) StartMatch(i int) {
 	g.Board = Board{}
+	g.Objective = Objective{}
+	g.Animator.Clear()
+	g.Overlays.Clear()
+	g.Effects.Clear()
+
+	board, objective, err := g.LoadMatch(i)
+	if err == nil {
+		g.Board = board
+		g.Objective = objective
+		return
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("match %d: %v", i, err)
+	}
 
 	switch i {
 	case 0:
@@ -14,7 +41,23 @@ func (g *Game) StartMatch(i int) {
 		g.Board.Match3()
 	case 4:
 		g.Board.Match4()
+	default:
+		rng := rand.New(rand.NewSource(g.Seed + int64(i)))
+		g.Board.GenerateMatch(i, rng)
+	}
+}
+
+// LoadMatch reads match i from the game's match directory, so matches saved
+// in the editor are played without rebuilding, or else from the embedded
+// assets.
+func (g *Game) LoadMatch(i int) (Board, Objective, error) {
+	if g.MatchDir != "" {
+		board, objective, err := LoadMatchFile(os.DirFS(g.MatchDir), MatchFileName(i))
+		if !errors.Is(err, fs.ErrNotExist) {
+			return board, objective, err
+		}
 	}
+	return LoadMatchFile(assets.FS, path.Join(MatchDirPath, MatchFileName(i)))
 }
 
 func (board *Board) Match0() {
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type MatchFile struct {
+	Tiles     [BoardHeight][BoardWidth]Tile
+	Terrain   [BoardHeight][BoardWidth]Terrain
+	Objective Objective
+}
+
+// DefaultMatchDir is the matches directory under the user config directory,
+// or empty if the system has none. Pass -matches ../assets/matches from src
+// to author the matches that are embedded in the game.
+func DefaultMatchDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, ConfigDirName, MatchDirPath)
+}
+
+func MatchFileName(i int) string {
+	return fmt.Sprintf("match_%02d.json", i)
+}
+
+func SaveMatchFile(path string, board *Board, objective Objective) error {
+	if err := objective.Validate(board); err != nil {
+		return err
+	}
+	file := MatchFile{
+		Tiles:     board.Tiles,
+		Terrain:   board.Terrain,
+		Objective: objective,
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadMatchFile reads a match from fsys: the match directory or the embedded
+// assets.
+func LoadMatchFile(fsys fs.FS, path string) (Board, Objective, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return Board{}, Objective{}, err
+	}
+	file := MatchFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Board{}, Objective{}, fmt.Errorf("%s: %w", path, err)
+	}
+	board := Board{Tiles: file.Tiles, Terrain: file.Terrain}
+	if err := file.Objective.Validate(&board); err != nil {
+		return Board{}, Objective{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return board, file.Objective, nil
+}
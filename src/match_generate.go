@@ -0,0 +1,155 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+)
+
+type Formation struct {
+	Name  string
+	King  Position
+	Slots []Position
+}
+
+var formations = []Formation{
+	{
+		Name: "Line",
+		King: Position{X: 4, Y: 0},
+		Slots: []Position{
+			{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1},
+			{X: 4, Y: 1}, {X: 5, Y: 1}, {X: 6, Y: 1}, {X: 7, Y: 1},
+			{X: 3, Y: 0}, {X: 5, Y: 0},
+		},
+	},
+	{
+		Name: "Wedge",
+		King: Position{X: 3, Y: 0},
+		Slots: []Position{
+			{X: 3, Y: 2}, {X: 2, Y: 1}, {X: 4, Y: 1}, {X: 3, Y: 1},
+			{X: 1, Y: 0}, {X: 5, Y: 0}, {X: 2, Y: 0}, {X: 4, Y: 0},
+		},
+	},
+	{
+		Name: "Fortress",
+		King: Position{X: 4, Y: 0},
+		Slots: []Position{
+			{X: 3, Y: 1}, {X: 4, Y: 1}, {X: 5, Y: 1}, {X: 3, Y: 0},
+			{X: 5, Y: 0}, {X: 2, Y: 1}, {X: 6, Y: 1},
+		},
+	},
+	{
+		Name: "Flanks",
+		King: Position{X: 4, Y: 0},
+		Slots: []Position{
+			{X: 0, Y: 2}, {X: 7, Y: 2}, {X: 0, Y: 1}, {X: 1, Y: 1},
+			{X: 6, Y: 1}, {X: 7, Y: 1}, {X: 3, Y: 0}, {X: 5, Y: 0},
+		},
+	},
+	{
+		Name: "Column",
+		King: Position{X: 3, Y: 0},
+		Slots: []Position{
+			{X: 3, Y: 1}, {X: 3, Y: 2}, {X: 4, Y: 1}, {X: 4, Y: 2},
+			{X: 2, Y: 0}, {X: 4, Y: 0},
+		},
+	},
+}
+
+func matchBudget(index int) float64 {
+	budget := MatchBudgetBase + MatchBudgetPerMatch*float64(max(index, 0))
+	if isEliteMatch(index) {
+		budget += EliteBudgetBonus
+	}
+	return budget
+}
+
+// isEliteMatch marks the generated matches that are tougher than usual and
+// reward a relic when won.
+func isEliteMatch(index int) bool {
+	return index > 4 && index%EliteMatchInterval == 0
+}
+
+// GenerateMatch fills the board with a black army whose total piece value
+// fits within the budget for the given match index.
+func (board *Board) GenerateMatch(index int, rng *rand.Rand) {
+	budget := matchBudget(index)
+
+	for range GenerateMatchAttempts {
+		candidate := Board{}
+		formation := formations[rng.Intn(len(formations))]
+		candidate.placeFormation(formation, budget, rng)
+
+		if candidate.validateMatch() {
+			*board = candidate
+			return
+		}
+	}
+	*board = Board{}
+	board.Match0()
+}
+
+func (board *Board) placeFormation(formation Formation, budget float64, rng *rand.Rand) {
+	king := randomPieceWithin(rng, budget/2)
+	board.Tiles[formation.King.Y][formation.King.X] = Tile{Piece: king, Color: Black, King: true}
+	budget -= pieceScores[king]
+
+	slots := slices.Clone(formation.Slots)
+	rng.Shuffle(len(slots), func(i, j int) {
+		slots[i], slots[j] = slots[j], slots[i]
+	})
+
+	for _, slot := range slots {
+		if budget < pieceScores[PiecePawn] {
+			break
+		}
+		piece := randomPieceWithin(rng, budget)
+		board.Tiles[slot.Y][slot.X] = Tile{Piece: piece, Color: Black}
+		budget -= pieceScores[piece]
+	}
+}
+
+// randomPieceWithin picks a random piece costing at most budget, falling back
+// to a pawn so that every slot it is asked to fill gets a piece.
+func randomPieceWithin(rng *rand.Rand, budget float64) Piece {
+	for range 16 {
+		piece := randomPiece(rng)
+		if piece != PieceEmpty && pieceScores[piece] <= budget {
+			return piece
+		}
+	}
+	return PiecePawn
+}
+
+// validateMatch rejects openings that are already lost for black: a missing
+// king, a black side without moves, or a king that is both trapped and
+// undefended.
+func (board *Board) validateMatch() bool {
+	kings := board.findKings(Black)
+	if len(kings) != 1 {
+		return false
+	}
+	if len(generateMovesForColor(board, Black)) == 0 {
+		return false
+	}
+
+	king := kings[0]
+	if len(getMoves(board, king.X, king.Y)) > 0 {
+		return true
+	}
+	return board.isDefended(king)
+}
+
+// isDefended reports whether another piece of the same color could recapture
+// on the given square.
+func (board *Board) isDefended(pos Position) bool {
+	color := board.Tiles[pos.Y][pos.X].Color
+	probe := *board
+	probe.Tiles[pos.Y][pos.X].Color = color.Opponent()
+
+	for _, move := range generateMovesForColor(&probe, color) {
+		if move.To == pos {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var menuColor = color.RGBA{0x1c, 0x18, 0x28, 0xff}
+var menuButtonColor = color.RGBA{0x48, 0x40, 0x58, 0xff}
+
+const menuButtonWidth = 100
+
+// NewMenuButton creates one of the wide text buttons the menus stack.
+func NewMenuButton(label string, anchor Anchor, y int, onClick func()) *Button {
+	return &Button{
+		Base: Base{
+			Layout:  Layout{Anchor: anchor, Y: y, W: menuButtonWidth, H: TileSize},
+			OnClick: onClick,
+		},
+		Background: menuButtonColor,
+		Text:       label,
+	}
+}
+
+// MenuScene is the title screen the game boots into. Outside a run, F2 opens
+// the match editor, which would otherwise overwrite the run's board.
+type MenuScene struct {
+	ui *UI
+}
+
+func (s *MenuScene) Enter(g *Game) {
+	s.Localize(g)
+}
+
+func (s *MenuScene) Localize(g *Game) {
+	title := &Label{
+		Base:  Base{Layout: Layout{Anchor: AnchorTop, Y: 40, W: LayoutWidth, H: TileSize * 2}},
+		Text:  T("menu.title"),
+		Style: TextStyle{Scale: TextScaleTitle, Align: text.AlignCenter},
+	}
+	newRun := NewMenuButton(T("menu.new_run"), AnchorTop, 96, func() { g.NewRun() })
+	resume := NewMenuButton(T("menu.continue"), AnchorTop, 116, func() { g.Scenes.Reset(g, &ArrangeScene{}) })
+	if !g.RunActive {
+		resume.Disabled = true
+		resume.Tooltip = T("menu.no_run")
+	}
+	settings := NewMenuButton(T("settings.title"), AnchorTop, 136, func() { g.Scenes.Push(g, &SettingsScene{}) })
+	quit := NewMenuButton(T("menu.quit"), AnchorTop, 156, func() { g.Quitting = true })
+	s.ui = NewUI(title, newRun, resume, settings, quit)
+}
+
+func (s *MenuScene) Exit(g *Game) {}
+
+func (s *MenuScene) Overlay() bool { return false }
+
+func (s *MenuScene) Music() Track { return TrackArrange }
+
+func (s *MenuScene) Update(g *Game) {
+	if !g.RunActive && inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		g.StartMatch(g.MatchIndex)
+		g.Scenes.Replace(g, &EditorScene{})
+		return
+	}
+	s.ui.Update()
+}
+
+func (s *MenuScene) Draw(g *Game, screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, LayoutWidth, LayoutHeight, menuColor, false)
+	s.ui.Draw(g, screen)
+}
+
+// PauseScene is the overlay Esc opens during a run. Leaving for the main
+// menu keeps the run, so it can be continued from there.
+type PauseScene struct {
+	ui *UI
+}
+
+func (s *PauseScene) Enter(g *Game) {
+	s.Localize(g)
+}
+
+func (s *PauseScene) Localize(g *Game) {
+	panel := &Panel{
+		Base:       Base{Layout: Layout{Anchor: AnchorCenter, W: menuButtonWidth + 16, H: 108}},
+		Background: panelColor,
+	}
+	panel.Widgets = append(panel.Widgets,
+		&Label{
+			Base:  Base{Layout: Layout{Anchor: AnchorTop, Y: 4, H: TileSize * 2}},
+			Text:  T("pause.title"),
+			Style: TextStyle{Scale: TextScaleTitle, Align: text.AlignCenter},
+		},
+		NewMenuButton(T("pause.resume"), AnchorTop, 40, func() { g.Scenes.Pop(g) }),
+		NewMenuButton(T("settings.title"), AnchorTop, 60, func() { g.Scenes.Push(g, &SettingsScene{}) }),
+		NewMenuButton(T("pause.menu"), AnchorTop, 80, func() { g.Scenes.Reset(g, &MenuScene{}) }),
+	)
+	s.ui = NewUI(panel)
+}
+
+func (s *PauseScene) Exit(g *Game) {}
+
+func (s *PauseScene) Overlay() bool { return true }
+
+func (s *PauseScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.Scenes.Pop(g)
+		return
+	}
+	s.ui.Update()
+}
+
+func (s *PauseScene) Draw(g *Game, screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, LayoutWidth, LayoutHeight, dimColor, false)
+	s.ui.Draw(g, screen)
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type ObjectiveKind int
+
+const (
+	ObjectiveCaptureKing ObjectiveKind = iota
+	ObjectiveSurvive
+	ObjectiveCaptureAll
+	ObjectiveEscort
+	ObjectiveProtect
+	ObjectiveCaptureKingInTime
+	objectiveKindCount
+)
+
+type ObjectiveStatus int
+
+const (
+	ObjectivePending ObjectiveStatus = iota
+	ObjectiveWon
+	ObjectiveLost
+)
+
+type Objective struct {
+	Kind   ObjectiveKind
+	Turns  int
+	Target Position
+}
+
+// ObjectiveRule decides the outcome of a match. Rules only look at the board,
+// so the same rule can judge simulated battles as well as the real one.
+type ObjectiveRule interface {
+	Name() string
+	Evaluate(objective Objective, board *Board) ObjectiveStatus
+	Progress(objective Objective, board *Board) string
+}
+
+var objectiveRules = map[ObjectiveKind]ObjectiveRule{
+	ObjectiveCaptureKing:       captureKingRule{},
+	ObjectiveSurvive:           surviveRule{},
+	ObjectiveCaptureAll:        captureAllRule{},
+	ObjectiveEscort:            escortRule{},
+	ObjectiveProtect:           protectRule{},
+	ObjectiveCaptureKingInTime: captureKingInTimeRule{},
+}
+
+func (objective Objective) Name() string {
+	return objectiveRules[objective.Kind].Name()
+}
+
+// Evaluate judges the board by the objective's rule. A battle the rule
+// leaves undecided after MaxBattleTurns is lost.
+func (objective Objective) Evaluate(board *Board) ObjectiveStatus {
+	status := objectiveRules[objective.Kind].Evaluate(objective, board)
+	if status == ObjectivePending && board.FullTurns() >= MaxBattleTurns {
+		return ObjectiveLost
+	}
+	return status
+}
+
+// UsesTurns reports whether the objective is judged against its Turns.
+func (objective Objective) UsesTurns() bool {
+	switch objective.Kind {
+	case ObjectiveSurvive, ObjectiveProtect, ObjectiveCaptureKingInTime:
+		return true
+	}
+	return false
+}
+
+// NeedsVIP reports whether the objective is about a King-flagged white
+// piece, which the match file or the player provides.
+func (objective Objective) NeedsVIP() bool {
+	return objective.Kind == ObjectiveEscort || objective.Kind == ObjectiveProtect
+}
+
+// Validate rejects objectives that would be decided before the battle
+// starts: a turn target outside 1 to MaxBattleTurns, or nothing to capture.
+func (objective Objective) Validate(board *Board) error {
+	if objective.UsesTurns() && (objective.Turns < 1 || objective.Turns > MaxBattleTurns) {
+		return fmt.Errorf("objective needs between 1 and %d turns, has %d", MaxBattleTurns, objective.Turns)
+	}
+	switch objective.Kind {
+	case ObjectiveCaptureKing, ObjectiveCaptureKingInTime:
+		if len(board.findKings(Black)) == 0 {
+			return errors.New("objective needs a black king")
+		}
+	case ObjectiveCaptureAll:
+		if board.countPieces(Black) == 0 {
+			return errors.New("objective needs black pieces")
+		}
+	}
+	return nil
+}
+
+func (objective Objective) Progress(board *Board) string {
+	return objectiveRules[objective.Kind].Progress(objective, board)
+}
+
+func (board *Board) FullTurns() int {
+	return board.Turn / 2
+}
+
+func (board *Board) countPieces(color Color) int {
+	count := 0
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			tile := board.Tiles[y][x]
+			if tile.Piece != PieceEmpty && tile.Color == color {
+				count += 1
+			}
+		}
+	}
+	return count
+}
+
+// DesignateLeader makes the white piece on the square the player's only
+// King-flagged piece, or takes the flag away if it already has it.
+func (board *Board) DesignateLeader(pos Position) {
+	tile := &board.Tiles[pos.Y][pos.X]
+	if tile.Piece == PieceEmpty || tile.Color != White {
+		return
+	}
+	leader := !tile.King
+	for _, king := range board.findKings(White) {
+		board.Tiles[king.Y][king.X].King = false
+	}
+	tile.King = leader
+}
+
+func (board *Board) findKings(color Color) []Position {
+	kings := []Position{}
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			tile := board.Tiles[y][x]
+			if tile.Piece != PieceEmpty && tile.Color == color && tile.King {
+				kings = append(kings, Position{X: x, Y: y})
+			}
+		}
+	}
+	return kings
+}
+
+type captureKingRule struct{}
+
+func (captureKingRule) Name() string {
+	return T("objective.capture_king.name")
+}
+
+func (captureKingRule) Evaluate(objective Objective, board *Board) ObjectiveStatus {
+	if len(board.findKings(Black)) == 0 {
+		return ObjectiveWon
+	}
+	if board.countPieces(White) == 0 {
+		return ObjectiveLost
+	}
+	return ObjectivePending
+}
+
+func (captureKingRule) Progress(objective Objective, board *Board) string {
+	return T("objective.capture_king.progress")
+}
+
+type surviveRule struct{}
+
+func (surviveRule) Name() string {
+	return T("objective.survive.name")
+}
+
+func (surviveRule) Evaluate(objective Objective, board *Board) ObjectiveStatus {
+	if board.countPieces(White) == 0 {
+		return ObjectiveLost
+	}
+	if board.FullTurns() >= objective.Turns {
+		return ObjectiveWon
+	}
+	return ObjectivePending
+}
+
+func (surviveRule) Progress(objective Objective, board *Board) string {
+	return T("objective.survive.progress", "turns", board.FullTurns(), "count", objective.Turns)
+}
+
+type captureAllRule struct{}
+
+func (captureAllRule) Name() string {
+	return T("objective.capture_all.name")
+}
+
+func (captureAllRule) Evaluate(objective Objective, board *Board) ObjectiveStatus {
+	if board.countPieces(Black) == 0 {
+		return ObjectiveWon
+	}
+	if board.countPieces(White) == 0 {
+		return ObjectiveLost
+	}
+	return ObjectivePending
+}
+
+func (captureAllRule) Progress(objective Objective, board *Board) string {
+	return T("objective.capture_all.progress", "count", board.countPieces(Black))
+}
+
+type escortRule struct{}
+
+func (escortRule) Name() string {
+	return T("objective.escort.name")
+}
+
+func (escortRule) Evaluate(objective Objective, board *Board) ObjectiveStatus {
+	vips := board.findKings(White)
+	if len(vips) == 0 {
+		return ObjectiveLost
+	}
+	for _, vip := range vips {
+		if vip == objective.Target {
+			return ObjectiveWon
+		}
+	}
+	return ObjectivePending
+}
+
+func (escortRule) Progress(objective Objective, board *Board) string {
+	return T("objective.escort.progress", "square", objective.Target)
+}
+
+type protectRule struct{}
+
+func (protectRule) Name() string {
+	return T("objective.protect.name")
+}
+
+func (protectRule) Evaluate(objective Objective, board *Board) ObjectiveStatus {
+	if len(board.findKings(White)) == 0 {
+		return ObjectiveLost
+	}
+	if board.FullTurns() >= objective.Turns {
+		return ObjectiveWon
+	}
+	return ObjectivePending
+}
+
+func (protectRule) Progress(objective Objective, board *Board) string {
+	return T("objective.protect.progress", "turns", board.FullTurns(), "count", objective.Turns)
+}
+
+type captureKingInTimeRule struct{}
+
+func (captureKingInTimeRule) Name() string {
+	return T("objective.capture_king_in_time.name")
+}
+
+func (captureKingInTimeRule) Evaluate(objective Objective, board *Board) ObjectiveStatus {
+	if len(board.findKings(Black)) == 0 {
+		return ObjectiveWon
+	}
+	if board.countPieces(White) == 0 || board.FullTurns() >= objective.Turns {
+		return ObjectiveLost
+	}
+	return ObjectivePending
+}
+
+func (captureKingInTimeRule) Progress(objective Objective, board *Board) string {
+	left := max(objective.Turns-board.FullTurns(), 0)
+	return T("objective.capture_king_in_time.progress", "count", left)
+}
@@ -0,0 +1,182 @@
+package main
+
+import "testing"
+
+// objectiveBoard builds a board from the given tiles, played up to fullTurns
+// turns.
+func objectiveBoard(fullTurns int, tiles map[Position]Tile) *Board {
+	board := &Board{Turn: fullTurns * 2}
+	for pos, tile := range tiles {
+		board.Tiles[pos.Y][pos.X] = tile
+	}
+	return board
+}
+
+func TestObjectiveEvaluate(t *testing.T) {
+	whiteRook := Tile{Piece: PieceRook, Color: White}
+	whiteVIP := Tile{Piece: PieceKnight, Color: White, King: true}
+	blackPawn := Tile{Piece: PiecePawn, Color: Black}
+	blackKing := Tile{Piece: PieceKing, Color: Black, King: true}
+	target := Position{X: 4, Y: 0}
+
+	tests := []struct {
+		name      string
+		objective Objective
+		fullTurns int
+		tiles     map[Position]Tile
+		want      ObjectiveStatus
+	}{
+		{
+			name:      "capture king pending",
+			objective: Objective{Kind: ObjectiveCaptureKing},
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 4, Y: 0}: blackKing},
+			want:      ObjectivePending,
+		},
+		{
+			name:      "capture king won",
+			objective: Objective{Kind: ObjectiveCaptureKing},
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 3, Y: 0}: blackPawn},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "capture king lost",
+			objective: Objective{Kind: ObjectiveCaptureKing},
+			tiles:     map[Position]Tile{{X: 4, Y: 0}: blackKing},
+			want:      ObjectiveLost,
+		},
+		{
+			name:      "capture king past the turn cap",
+			objective: Objective{Kind: ObjectiveCaptureKing},
+			fullTurns: MaxBattleTurns,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 4, Y: 0}: blackKing},
+			want:      ObjectiveLost,
+		},
+		{
+			name:      "survive pending",
+			objective: Objective{Kind: ObjectiveSurvive, Turns: 5},
+			fullTurns: 4,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 4, Y: 0}: blackKing},
+			want:      ObjectivePending,
+		},
+		{
+			name:      "survive won",
+			objective: Objective{Kind: ObjectiveSurvive, Turns: 5},
+			fullTurns: 5,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 4, Y: 0}: blackKing},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "survive lost",
+			objective: Objective{Kind: ObjectiveSurvive, Turns: 5},
+			fullTurns: 2,
+			tiles:     map[Position]Tile{{X: 4, Y: 0}: blackKing},
+			want:      ObjectiveLost,
+		},
+		{
+			name:      "survive up to the turn cap",
+			objective: Objective{Kind: ObjectiveSurvive, Turns: MaxBattleTurns},
+			fullTurns: MaxBattleTurns,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 4, Y: 0}: blackKing},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "capture all pending",
+			objective: Objective{Kind: ObjectiveCaptureAll},
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 3, Y: 0}: blackPawn},
+			want:      ObjectivePending,
+		},
+		{
+			name:      "capture all won",
+			objective: Objective{Kind: ObjectiveCaptureAll},
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "escort pending",
+			objective: Objective{Kind: ObjectiveEscort, Target: target},
+			tiles:     map[Position]Tile{{X: 4, Y: 5}: whiteVIP, {X: 0, Y: 0}: blackKing},
+			want:      ObjectivePending,
+		},
+		{
+			name:      "escort won",
+			objective: Objective{Kind: ObjectiveEscort, Target: target},
+			tiles:     map[Position]Tile{target: whiteVIP, {X: 0, Y: 0}: blackKing},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "escort lost without a VIP",
+			objective: Objective{Kind: ObjectiveEscort, Target: target},
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 0, Y: 0}: blackKing},
+			want:      ObjectiveLost,
+		},
+		{
+			name:      "protect won",
+			objective: Objective{Kind: ObjectiveProtect, Turns: 3},
+			fullTurns: 3,
+			tiles:     map[Position]Tile{{X: 4, Y: 5}: whiteVIP, {X: 0, Y: 0}: blackKing},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "protect lost",
+			objective: Objective{Kind: ObjectiveProtect, Turns: 3},
+			fullTurns: 1,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 0, Y: 0}: blackKing},
+			want:      ObjectiveLost,
+		},
+		{
+			name:      "capture king in time won",
+			objective: Objective{Kind: ObjectiveCaptureKingInTime, Turns: 3},
+			fullTurns: 2,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook},
+			want:      ObjectiveWon,
+		},
+		{
+			name:      "capture king in time lost",
+			objective: Objective{Kind: ObjectiveCaptureKingInTime, Turns: 3},
+			fullTurns: 3,
+			tiles:     map[Position]Tile{{X: 0, Y: 7}: whiteRook, {X: 4, Y: 0}: blackKing},
+			want:      ObjectiveLost,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			board := objectiveBoard(test.fullTurns, test.tiles)
+			if got := test.objective.Evaluate(board); got != test.want {
+				t.Errorf("Evaluate() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestObjectiveValidate(t *testing.T) {
+	withKing := objectiveBoard(0, map[Position]Tile{{X: 4, Y: 0}: {Piece: PieceKing, Color: Black, King: true}})
+	withoutKing := objectiveBoard(0, map[Position]Tile{{X: 4, Y: 0}: {Piece: PiecePawn, Color: Black}})
+	empty := objectiveBoard(0, nil)
+
+	tests := []struct {
+		name      string
+		objective Objective
+		board     *Board
+		valid     bool
+	}{
+		{"capture king", Objective{Kind: ObjectiveCaptureKing}, withKing, true},
+		{"capture king without a king", Objective{Kind: ObjectiveCaptureKing}, withoutKing, false},
+		{"capture king in time without a king", Objective{Kind: ObjectiveCaptureKingInTime, Turns: 5}, withoutKing, false},
+		{"capture all", Objective{Kind: ObjectiveCaptureAll}, withoutKing, true},
+		{"capture all without enemies", Objective{Kind: ObjectiveCaptureAll}, empty, false},
+		{"survive", Objective{Kind: ObjectiveSurvive, Turns: 1}, empty, true},
+		{"survive zero turns", Objective{Kind: ObjectiveSurvive}, empty, false},
+		{"protect past the turn cap", Objective{Kind: ObjectiveProtect, Turns: MaxBattleTurns + 1}, empty, false},
+		{"escort", Objective{Kind: ObjectiveEscort}, empty, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.objective.Validate(test.board)
+			if (err == nil) != test.valid {
+				t.Errorf("Validate() = %v, want valid %t", err, test.valid)
+			}
+		})
+	}
+}
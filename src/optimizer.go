@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type Placement struct {
+	HandIndex int
+	Card      Card
+	Position  Position
+}
+
+// Optimizer searches for a good placement of the unit cards in the hand with
+// simulated annealing, scoring each arrangement with a shallow search.
+type Optimizer struct {
+	mu         sync.Mutex
+	running    bool
+	suggestion []Placement
+	source     Board
+	hand       []Card
+	cancel     context.CancelFunc
+	generation int
+}
+
+func (g *Game) UpdateOptimizer() {
+	optimizer := g.Optimizer
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		optimizer.Start(g.Board, g.Hand.Cards, g.Relics)
+	}
+
+	suggestion, ok := optimizer.Suggestion(g.Board, g.Hand.Cards)
+	if !ok {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		g.AcceptPlacements(suggestion)
+		optimizer.Stop()
+	}
+}
+
+// AcceptPlacements puts a suggestion on the board as if each piece had been
+// placed by hand.
+func (g *Game) AcceptPlacements(placements []Placement) {
+	indices := []int{}
+	for _, placement := range placements {
+		pos := placement.Position
+		card := placement.Card
+		tile := Tile{Piece: card.Piece, Color: White, Level: card.Level, Unit: card.Unit}
+		g.Board.Tiles[pos.Y][pos.X] = tile
+		indices = append(indices, placement.HandIndex)
+		g.Events.Emit(Event{Kind: EventPlace, Tile: tile, Position: pos})
+	}
+	slices.Sort(indices)
+	for _, i := range slices.Backward(indices) {
+		g.Hand.Remove(i)
+	}
+}
+
+func (optimizer *Optimizer) Start(board Board, hand []Card, relics []Relic) {
+	optimizer.Stop()
+
+	placements := []Placement{}
+	for i, card := range hand {
+		if !card.IsSpell() {
+			placements = append(placements, Placement{HandIndex: i, Card: card})
+		}
+	}
+	relics = slices.Clone(relics)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	optimizer.cancel = cancel
+	optimizer.mu.Lock()
+	optimizer.generation += 1
+	generation := optimizer.generation
+	optimizer.running = true
+	optimizer.suggestion = nil
+	optimizer.source = board
+	optimizer.hand = slices.Clone(hand)
+	optimizer.mu.Unlock()
+
+	go func() {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		best := optimizeArrangement(ctx, board, placements, relics, rng)
+
+		optimizer.mu.Lock()
+		defer optimizer.mu.Unlock()
+		if optimizer.generation != generation {
+			return
+		}
+		if ctx.Err() == nil {
+			optimizer.suggestion = best
+		}
+		optimizer.running = false
+	}()
+}
+
+func (optimizer *Optimizer) Stop() {
+	if optimizer.cancel != nil {
+		optimizer.cancel()
+		optimizer.cancel = nil
+	}
+	optimizer.mu.Lock()
+	optimizer.generation += 1
+	optimizer.running = false
+	optimizer.suggestion = nil
+	optimizer.mu.Unlock()
+}
+
+// Suggestion returns the finished arrangement, as long as neither the board
+// nor the hand has changed since the search started. Placements refer to
+// hand indices, so a suggestion for another hand would place the wrong cards.
+func (optimizer *Optimizer) Suggestion(board Board, hand []Card) ([]Placement, bool) {
+	optimizer.mu.Lock()
+	defer optimizer.mu.Unlock()
+	if optimizer.suggestion == nil || optimizer.source != board || !slices.Equal(optimizer.hand, hand) {
+		return nil, false
+	}
+	return optimizer.suggestion, true
+}
+
+func (optimizer *Optimizer) Running() bool {
+	optimizer.mu.Lock()
+	defer optimizer.mu.Unlock()
+	return optimizer.running
+}
+
+func optimizeArrangement(ctx context.Context, board Board, placements []Placement, relics []Relic, rng *rand.Rand) []Placement {
+	squares := board.placementSquares()
+	rng.Shuffle(len(squares), func(i, j int) {
+		squares[i], squares[j] = squares[j], squares[i]
+	})
+	placements = placements[:min(len(placements), len(squares))]
+	if len(placements) == 0 {
+		return placements
+	}
+
+	for i := range placements {
+		placements[i].Position = squares[i]
+	}
+	free := squares[len(placements):]
+	score := scoreArrangement(board, placements, relics)
+	best, bestScore := slices.Clone(placements), score
+
+	for i := range OptimizerIterations {
+		if ctx.Err() != nil {
+			break
+		}
+		temperature := OptimizerTemperature * (1 - float64(i)/OptimizerIterations)
+
+		// a neighbour either swaps two pieces or moves one to a free square
+		candidate := slices.Clone(placements)
+		candidateFree := slices.Clone(free)
+		a := rng.Intn(len(candidate))
+		if len(candidateFree) == 0 || (len(candidate) > 1 && rng.Intn(2) == 0) {
+			b := rng.Intn(len(candidate))
+			candidate[a].Position, candidate[b].Position = candidate[b].Position, candidate[a].Position
+		} else {
+			f := rng.Intn(len(candidateFree))
+			candidate[a].Position, candidateFree[f] = candidateFree[f], candidate[a].Position
+		}
+
+		candidateScore := scoreArrangement(board, candidate, relics)
+		delta := candidateScore - score
+		if delta < 0 && rng.Float64() >= math.Exp(delta/math.Max(temperature, 1e-6)) {
+			continue
+		}
+		placements, free, score = candidate, candidateFree, candidateScore
+		if score > bestScore {
+			best, bestScore = slices.Clone(placements), score
+		}
+	}
+	return best
+}
+
+func scoreArrangement(board Board, placements []Placement, relics []Relic) float64 {
+	for _, placement := range placements {
+		pos := placement.Position
+		card := placement.Card
+		board.Tiles[pos.Y][pos.X] = Tile{Piece: card.Piece, Color: White, Level: card.Level, Unit: card.Unit}
+	}
+	board.Rules[White] = playerRules(&board, relics)
+	board.Turn = 0
+
+	_, value, _ := negamax(&board, OptimizerDepth, math.Inf(-1), math.Inf(1))
+	return value
+}
+
+func (g *Game) DrawOptimizer(screen *ebiten.Image) {
+	if g.Optimizer.Running() {
+		g.Graphics.DrawText(screen, T("optimizer.thinking"), 4, float64(TileSize*13+2))
+	}
+	suggestion, ok := g.Optimizer.Suggestion(g.Board, g.Hand.Cards)
+	if !ok {
+		return
+	}
+
+	for _, placement := range suggestion {
+		x := float64(g.Graphics.Board.ScreenX + placement.Position.X*TileSize)
+		y := float64(g.Graphics.Board.ScreenY + placement.Position.Y*TileSize)
+		opt := g.Graphics.Position(x, y)
+		opt.ColorScale.ScaleAlpha(0.5)
+		screen.DrawImage(Sprites[TileToSprite[White][placement.Card.Piece]], &opt)
+	}
+	g.Graphics.DrawText(screen, T("optimizer.accept"), 4, float64(TileSize*13+2))
+}
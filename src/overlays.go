@@ -0,0 +1,122 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+var lastMoveColor = color.RGBA{0xe0, 0xd0, 0x60, 0x50}
+var targetColor = color.RGBA{0x60, 0xc0, 0x60, 0xa0}
+var whiteAttackColor = color.RGBA{0x20, 0x40, 0x80, 0x40}
+var blackAttackColor = color.RGBA{0x80, 0x20, 0x20, 0x40}
+var dangerColor = color.RGBA{0xf0, 0x30, 0x30, 0xff}
+
+// Overlays tracks what the board overlays need beyond the board itself: the
+// last move played, the piece the player has selected and the square the
+// cursor rests on.
+type Overlays struct {
+	LastMove    Move
+	HasLastMove bool
+	Selected    Position
+	HasSelected bool
+	Hovered     Position
+	HoverTicks  int
+}
+
+func (overlays *Overlays) HandleEvent(event Event) {
+	switch event.Kind {
+	case EventMove:
+		overlays.LastMove = event.Move
+		overlays.HasLastMove = true
+	case EventBattleStart:
+		overlays.Clear()
+	}
+}
+
+func (overlays *Overlays) Clear() {
+	*overlays = Overlays{}
+}
+
+// Select toggles the selection of the square.
+func (overlays *Overlays) Select(pos Position) {
+	if overlays.HasSelected && overlays.Selected == pos {
+		overlays.HasSelected = false
+		return
+	}
+	overlays.Selected = pos
+	overlays.HasSelected = true
+}
+
+// focusedPiece returns the piece whose targets are shown: the hovered one,
+// or else the selected one.
+func (g *Game) focusedPiece() (Position, bool) {
+	mx, my := ebiten.CursorPosition()
+	if x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my); ok && g.Board.Tiles[y][x].Piece != PieceEmpty {
+		return Position{X: x, Y: y}, true
+	}
+	selected := g.Overlays.Selected
+	if g.Overlays.HasSelected && g.Board.Tiles[selected.Y][selected.X].Piece != PieceEmpty {
+		return selected, true
+	}
+	return Position{}, false
+}
+
+func (g *Game) DrawOverlays(screen *ebiten.Image) {
+	settings := g.Settings
+	originX := float32(g.Graphics.Board.ScreenX)
+	originY := float32(g.Graphics.Board.ScreenY)
+	fill := func(pos Position, clr color.Color) {
+		vector.FillRect(screen, originX+float32(pos.X*TileSize), originY+float32(pos.Y*TileSize), TileSize, TileSize, clr, false)
+	}
+
+	if settings.ShowAttacks {
+		white := g.Board.attackedSquares(White)
+		black := g.Board.attackedSquares(Black)
+		for y := range BoardHeight {
+			for x := range BoardWidth {
+				if white[y][x] {
+					fill(Position{X: x, Y: y}, whiteAttackColor)
+				}
+				if black[y][x] {
+					fill(Position{X: x, Y: y}, blackAttackColor)
+				}
+			}
+		}
+	}
+
+	if settings.ShowLastMove && g.Overlays.HasLastMove {
+		fill(g.Overlays.LastMove.From, lastMoveColor)
+		fill(g.Overlays.LastMove.To, lastMoveColor)
+	}
+
+	if pos, ok := g.focusedPiece(); settings.ShowTargets && ok && !g.Drag.Active {
+		board := g.Board
+		board.Rules[White] = g.PlayerRules()
+		for _, move := range filterSelfCaptures(&board, getMoves(&board, pos.X, pos.Y)) {
+			cx := originX + float32(move.To.X*TileSize) + TileSize/2
+			cy := originY + float32(move.To.Y*TileSize) + TileSize/2
+			vector.FillCircle(screen, cx, cy, 3, targetColor, true)
+		}
+		opt := g.Graphics.Position(float64(originX)+float64(pos.X*TileSize), float64(originY)+float64(pos.Y*TileSize))
+		screen.DrawImage(Sprites[SpriteHover], &opt)
+	}
+
+	if settings.ShowDanger {
+		for _, side := range []Color{White, Black} {
+			attacked := g.Board.attackedSquares(side.Opponent())
+			for y := range BoardHeight {
+				for x := range BoardWidth {
+					tile := g.Board.Tiles[y][x]
+					if tile.Piece == PieceEmpty || !tile.King || tile.Color != side || !attacked[y][x] {
+						continue
+					}
+					px := originX + float32(x*TileSize)
+					py := originY + float32(y*TileSize)
+					vector.StrokeRect(screen, px+1, py+1, TileSize-2, TileSize-2, 1, dangerColor, false)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Relic int
+
+const (
+	RelicNone Relic = iota
+	RelicSixthRank
+	RelicBounty
+	RelicDoubleStep
+	RelicWarChest
+	RelicHorseshoe
+)
+
+// RelicHooks describes how a relic changes a run. Every hook is optional.
+// Rules is applied to the player's rules when a battle starts and is
+// therefore seen by move generation, ApplyMove and evaluate, while the
+// remaining hooks feed into the economy. Name and Description are string
+// keys.
+type RelicHooks struct {
+	Name        string
+	Symbol      string
+	Description string
+	Rules       RuleModifier
+	OnCapture   func(g *Game, captured Tile)
+	OnWin       func(g *Game)
+}
+
+var relics = map[Relic]RelicHooks{
+	RelicSixthRank: {
+		Name:        "relic.sixth_rank.name",
+		Symbol:      "6",
+		Description: "relic.sixth_rank.description",
+		Rules: func(rules *Rules) {
+			rules.PromotionRanksEarly += 2
+		},
+	},
+	RelicBounty: {
+		Name:        "relic.bounty.name",
+		Symbol:      "B",
+		Description: "relic.bounty.description",
+		OnCapture: func(g *Game, captured Tile) {
+			if g.BattleCaptures == 1 {
+				g.Gold += BountyGold
+			}
+		},
+	},
+	RelicDoubleStep: {
+		Name:        "relic.double_step.name",
+		Symbol:      "D",
+		Description: "relic.double_step.description",
+		Rules: func(rules *Rules) {
+			rules.KingDoubleMove = true
+		},
+	},
+	RelicWarChest: {
+		Name:        "relic.war_chest.name",
+		Symbol:      "W",
+		Description: "relic.war_chest.description",
+		OnWin: func(g *Game) {
+			g.Gold += WarChestGold
+		},
+	},
+	RelicHorseshoe: {
+		Name:        "relic.horseshoe.name",
+		Symbol:      "H",
+		Description: "relic.horseshoe.description",
+		Rules: func(rules *Rules) {
+			rules.KnightExtraLeaps += 1
+		},
+	},
+}
+
+func (g *Game) HasRelic(relic Relic) bool {
+	for _, owned := range g.Relics {
+		if owned == relic {
+			return true
+		}
+	}
+	return false
+}
+
+// RandomUnownedRelic returns a relic the player does not have yet, or
+// RelicNone when every relic is owned.
+func (g *Game) RandomUnownedRelic(rng *rand.Rand) Relic {
+	candidates := []Relic{}
+	for relic := range relics {
+		if !g.HasRelic(relic) {
+			candidates = append(candidates, relic)
+		}
+	}
+	if len(candidates) == 0 {
+		return RelicNone
+	}
+	// map iteration order is random, so sort before picking for a
+	// reproducible choice from the seeded rng
+	slices.Sort(candidates)
+	return candidates[rng.Intn(len(candidates))]
+}
+
+func (g *Game) PlayerRules() Rules {
+	return playerRules(&g.Board, g.Relics)
+}
+
+func playerRules(board *Board, owned []Relic) Rules {
+	rules := board.ActiveRules(White)
+	for _, relic := range owned {
+		if modify := relics[relic].Rules; modify != nil {
+			modify(&rules)
+		}
+	}
+	return rules
+}
+
+func (g *Game) OnCapture(captured Tile) {
+	g.BattleCaptures += 1
+	for _, relic := range g.Relics {
+		if hook := relics[relic].OnCapture; hook != nil {
+			hook(g, captured)
+		}
+	}
+}
+
+func (g *Game) OnWin() {
+	g.Gold += MatchRewardGold
+	for _, relic := range g.Relics {
+		if hook := relics[relic].OnWin; hook != nil {
+			hook(g)
+		}
+	}
+	if isEliteMatch(g.MatchIndex) {
+		rng := rand.New(rand.NewSource(g.Seed + int64(g.MatchIndex)))
+		if relic := g.RandomUnownedRelic(rng); relic != RelicNone {
+			g.GrantRelic(relic)
+		}
+	}
+}
+
+func (g *Game) DrawRelicBar(screen *ebiten.Image) {
+	y := float64(LayoutHeight - TileSize - 4)
+	for i, relic := range g.Relics {
+		g.DrawRelic(screen, relic, 4+float64(i)*(TileSize+2), y)
+	}
+	g.Graphics.DrawText(screen, T("gold", "amount", g.Gold), LayoutWidth-60, float64(LayoutHeight-8))
+}
+
+func (g *Game) DrawRelic(screen *ebiten.Image, relic Relic, x, y float64) {
+	opt := g.Graphics.Position(x, y)
+	screen.DrawImage(Sprites[SpriteButtonSmall], &opt)
+	g.Graphics.DrawText(screen, relics[relic].Symbol, x+5, y+12)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// RunStats counts what happened over a run for the summary screen.
+type RunStats struct {
+	Wins     int
+	Losses   int
+	Captures int
+	Fallen   int // units captured by the enemy, whether or not permadeath kept them
+}
+
+// NewRun throws away the current run and starts a fresh one with a new
+// seed, starting deck and shop.
+func (g *Game) NewRun() {
+	g.Seed = time.Now().UnixNano()
+	g.Deck = Deck{DrawCount: 3}
+	g.Hand = Hand{SelectIndex: -1}
+	g.Units = map[UnitID]*Unit{}
+	g.NextUnitID = 0
+	g.Gold = StartingGold
+	g.Relics = nil
+	g.Shop = Shop{}
+	g.MatchIndex = 0
+	g.Stats = RunStats{}
+	g.Permadeath = g.Settings.Permadeath
+	g.RunActive = true
+
+	g.AddToDeck(Card{Piece: PiecePawn})
+	g.AddToDeck(Card{Spell: SpellFreeze})
+
+	g.Shop.items = append(g.Shop.items, ShopItem{Card: Card{Piece: PieceKnight}, Price: 3})
+	g.Shop.items = append(g.Shop.items, ShopItem{Card: Card{Spell: SpellSwap}, Price: 2})
+	g.Shop.items = append(g.Shop.items, ShopItem{Card: Card{Spell: SpellPromote}, Price: 4})
+	g.Shop.items = append(g.Shop.items, ShopItem{Card: Card{Spell: SpellWall}, Price: 2})
+	g.Shop.items = append(g.Shop.items, ShopItem{Relic: RelicBounty, Price: 6})
+
+	g.AddCardsFromDeckToHand()
+	g.StartMatch(g.MatchIndex)
+	g.Scenes.Reset(g, &ArrangeScene{})
+}
+
+// RunStatus reports whether the run is over. It is won by clearing
+// RunLength matches, and lost after MaxLosses defeats or once no unit cards
+// are left to field.
+func (g *Game) RunStatus() ObjectiveStatus {
+	if g.MatchIndex >= RunLength {
+		return ObjectiveWon
+	}
+	hasUnits := slices.ContainsFunc(g.Deck.Cards, func(card Card) bool { return !card.IsSpell() })
+	if g.Stats.Losses >= MaxLosses || !hasUnits {
+		return ObjectiveLost
+	}
+	return ObjectivePending
+}
+
+// canPause reports whether Esc should open the pause menu, which it does in
+// every scene of a run but not in menus or overlays.
+func (g *Game) canPause() bool {
+	switch top := g.Scenes.Top().(type) {
+	case nil, *MenuScene, *RunSummaryScene:
+		return false
+	default:
+		return !top.Overlay()
+	}
+}
+
+var victoryColor = color.RGBA{0x20, 0x30, 0x20, 0xff}
+var defeatColor = color.RGBA{0x30, 0x18, 0x18, 0xff}
+
+// RunSummaryScene ends a run with its stats and seed, then returns to the
+// main menu.
+type RunSummaryScene struct {
+	Won bool
+	ui  *UI
+}
+
+func (s *RunSummaryScene) Enter(g *Game) {
+	g.RunActive = false
+	s.Localize(g)
+}
+
+func (s *RunSummaryScene) Localize(g *Game) {
+	title := T("summary.defeat")
+	if s.Won {
+		title = T("summary.victory")
+	}
+	lines := []string{
+		T("summary.wins", "count", g.Stats.Wins, "total", RunLength),
+		T("summary.losses", "count", g.Stats.Losses, "max", MaxLosses),
+		T("summary.captures", "count", g.Stats.Captures),
+		T("summary.fallen", "count", g.Stats.Fallen),
+		T("summary.relics", "count", len(g.Relics)),
+		T("gold", "amount", g.Gold),
+		T("summary.seed", "seed", fmt.Sprint(g.Seed)),
+	}
+
+	panel := &Panel{Base: Base{Layout: Layout{Anchor: AnchorCenter, W: 200, H: 200}}}
+	panel.Widgets = append(panel.Widgets, &Label{
+		Base:  Base{Layout: Layout{Anchor: AnchorTop, W: 200, H: TileSize * 2}},
+		Text:  title,
+		Style: TextStyle{Scale: TextScaleTitle, Align: text.AlignCenter},
+	})
+	for i, line := range lines {
+		panel.Widgets = append(panel.Widgets, &Label{
+			Base: Base{Layout: Layout{X: 8, Y: TileSize*2 + 4 + i*14, W: 184, H: 14}},
+			Text: line,
+		})
+	}
+	panel.Widgets = append(panel.Widgets, NewMenuButton(T("summary.menu"), AnchorBottom, 0, func() {
+		g.Scenes.Reset(g, &MenuScene{})
+	}))
+	s.ui = NewUI(panel)
+}
+
+func (s *RunSummaryScene) Exit(g *Game) {}
+
+func (s *RunSummaryScene) Overlay() bool { return false }
+
+func (s *RunSummaryScene) Update(g *Game) {
+	s.ui.Update()
+}
+
+func (s *RunSummaryScene) Draw(g *Game, screen *ebiten.Image) {
+	background := defeatColor
+	if s.Won {
+		background = victoryColor
+	}
+	vector.FillRect(screen, 0, 0, LayoutWidth, LayoutHeight, background, false)
+	s.ui.Draw(g, screen)
+}
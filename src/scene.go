@@ -0,0 +1,95 @@
+package main
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Scene is one screen of the game. Only the top scene of the stack receives
+// input; scenes that report Overlay draw on top of the scene beneath them.
+type Scene interface {
+	Enter(g *Game)
+	Exit(g *Game)
+	Update(g *Game)
+	Draw(g *Game, screen *ebiten.Image)
+	Overlay() bool
+}
+
+// Localizer is implemented by scenes whose widgets hold translated text.
+// Localize builds those widgets, and runs again when the language changes.
+type Localizer interface {
+	Localize(g *Game)
+}
+
+type SceneStack struct {
+	scenes []Scene
+}
+
+func (stack *SceneStack) Top() Scene {
+	if len(stack.scenes) == 0 {
+		return nil
+	}
+	return stack.scenes[len(stack.scenes)-1]
+}
+
+func (stack *SceneStack) Push(g *Game, scene Scene) {
+	stack.scenes = append(stack.scenes, scene)
+	scene.Enter(g)
+}
+
+func (stack *SceneStack) Pop(g *Game) {
+	top := stack.Top()
+	if top == nil {
+		return
+	}
+	stack.scenes = stack.scenes[:len(stack.scenes)-1]
+	top.Exit(g)
+}
+
+// Replace swaps the top scene for another one.
+func (stack *SceneStack) Replace(g *Game, scene Scene) {
+	stack.Pop(g)
+	stack.Push(g, scene)
+}
+
+// Reset exits every scene on the stack and starts over from the given one.
+func (stack *SceneStack) Reset(g *Game, scene Scene) {
+	for len(stack.scenes) > 0 {
+		stack.Pop(g)
+	}
+	stack.Push(g, scene)
+}
+
+func (stack *SceneStack) Localize(g *Game) {
+	for _, scene := range stack.scenes {
+		if localizer, ok := scene.(Localizer); ok {
+			localizer.Localize(g)
+		}
+	}
+}
+
+// Music is the track of the topmost scene that has one.
+func (stack *SceneStack) Music() Track {
+	for i := len(stack.scenes) - 1; i >= 0; i-- {
+		if scene, ok := stack.scenes[i].(MusicScene); ok {
+			return scene.Music()
+		}
+	}
+	return TrackNone
+}
+
+func (stack *SceneStack) Update(g *Game) {
+	if top := stack.Top(); top != nil {
+		top.Update(g)
+	}
+}
+
+func (stack *SceneStack) Draw(g *Game, screen *ebiten.Image) {
+	base := 0
+	for i := len(stack.scenes) - 1; i >= 0; i-- {
+		if !stack.scenes[i].Overlay() {
+			base = i
+			break
+		}
+	}
+	for _, scene := range stack.scenes[base:] {
+		scene.Draw(g, screen)
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type Settings struct {
+	Language     string
+	ShowLastMove bool
+	ShowTargets  bool
+	ShowAttacks  bool
+	ShowDanger   bool
+	Mute         bool
+	MusicVolume  float64 // 0 to 1
+	SoundVolume  float64 // 0 to 1
+	Permadeath   bool    // taken up by the next new run
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Language:     FallbackLanguage,
+		ShowLastMove: true,
+		ShowTargets:  true,
+		ShowDanger:   true,
+		MusicVolume:  0.5,
+		SoundVolume:  0.8,
+	}
+}
+
+type SettingToggle struct {
+	Label string
+	Value func(settings *Settings) *bool
+}
+
+var settingToggles = []SettingToggle{
+	{"settings.last_move", func(settings *Settings) *bool { return &settings.ShowLastMove }},
+	{"settings.targets", func(settings *Settings) *bool { return &settings.ShowTargets }},
+	{"settings.attacks", func(settings *Settings) *bool { return &settings.ShowAttacks }},
+	{"settings.danger", func(settings *Settings) *bool { return &settings.ShowDanger }},
+	{"settings.mute", func(settings *Settings) *bool { return &settings.Mute }},
+	{"settings.permadeath", func(settings *Settings) *bool { return &settings.Permadeath }},
+}
+
+type VolumeSetting struct {
+	Label string
+	Value func(settings *Settings) *float64
+}
+
+var volumeSettings = []VolumeSetting{
+	{"settings.music_volume", func(settings *Settings) *float64 { return &settings.MusicVolume }},
+	{"settings.sound_volume", func(settings *Settings) *float64 { return &settings.SoundVolume }},
+}
+
+var dimColor = color.RGBA{0x00, 0x00, 0x00, 0x80}
+var panelColor = color.RGBA{0x2c, 0x28, 0x38, 0xf0}
+
+// CycleLanguage switches to the next loaded language and rebuilds every
+// scene's text.
+func (g *Game) CycleLanguage() {
+	codes := translator.Codes()
+	next := codes[(slices.Index(codes, g.Settings.Language)+1)%len(codes)]
+	g.Settings.Language = next
+	translator.SetLanguage(next)
+	g.Scenes.Localize(g)
+}
+
+// NewSettingsButton creates the gear button that opens the settings overlay.
+func NewSettingsButton(g *Game) *Button {
+	return &Button{
+		Base: Base{
+			Layout:  Layout{Anchor: AnchorTopRight, X: -4, W: TileSize, H: TileSize},
+			Tooltip: T("settings.title"),
+			OnClick: func() { g.Scenes.Push(g, &SettingsScene{}) },
+		},
+		Sprite: Sprites[SpriteButtonSmall],
+		Icon:   Sprites[SpriteIconGear],
+	}
+}
+
+// SettingsScene is an overlay listing every toggle in settingToggles.
+type SettingsScene struct {
+	ui *UI
+}
+
+func (s *SettingsScene) Enter(g *Game) {
+	s.Localize(g)
+}
+
+func (s *SettingsScene) Localize(g *Game) {
+	rows := len(settingToggles) + 1 + len(volumeSettings)
+	panel := &Panel{
+		Base:       Base{Layout: Layout{Anchor: AnchorCenter, W: 180, H: rows*TileSize + 40}},
+		Background: panelColor,
+	}
+	panel.Widgets = append(panel.Widgets, &Label{
+		Base:  Base{Layout: Layout{X: 8, Y: 4, W: 140, H: TileSize * 2}},
+		Text:  T("settings.title"),
+		Style: TextStyle{Scale: TextScaleTitle},
+	})
+
+	var boxes []*Button
+	for i, toggle := range settingToggles {
+		y := i*TileSize + 32
+		box := &Button{
+			Base: Base{
+				Layout: Layout{X: 8, Y: y, W: TileSize, H: TileSize},
+				OnClick: func() {
+					value := toggle.Value(&g.Settings)
+					*value = !*value
+				},
+			},
+		}
+		label := &Label{
+			Base: Base{Layout: Layout{X: 8 + TileSize + 4, Y: y, W: 140, H: TileSize}},
+			Text: T(toggle.Label),
+		}
+		boxes = append(boxes, box)
+		panel.Widgets = append(panel.Widgets, box, label)
+	}
+
+	y := len(settingToggles)*TileSize + 32
+	language := &Button{
+		Base: Base{
+			Layout:  Layout{X: 8, Y: y, W: TileSize, H: TileSize},
+			Tooltip: T("settings.next_language"),
+			OnClick: func() { g.CycleLanguage() },
+		},
+		Sprite: Sprites[SpriteButtonSmall],
+		Text:   ">",
+	}
+	languageLabel := &Label{
+		Base: Base{Layout: Layout{X: 8 + TileSize + 4, Y: y, W: 140, H: TileSize}},
+		Text: T("settings.language", "name", T("language.name")),
+	}
+	panel.Widgets = append(panel.Widgets, language, languageLabel)
+
+	var volumeLabels []*Label
+	for i, volume := range volumeSettings {
+		y := (len(settingToggles)+1+i)*TileSize + 32
+		step := func(delta float64) func() {
+			return func() {
+				value := volume.Value(&g.Settings)
+				*value = min(max(*value+delta, 0), 1)
+			}
+		}
+		down := &Button{
+			Base: Base{
+				Layout:  Layout{X: 8, Y: y, W: TileSize, H: TileSize},
+				Tooltip: T("settings.volume_down"),
+				OnClick: step(-VolumeStep),
+			},
+			Sprite: Sprites[SpriteButtonSmall],
+			Text:   "-",
+		}
+		up := &Button{
+			Base: Base{
+				Layout:  Layout{X: 8 + TileSize + 2, Y: y, W: TileSize, H: TileSize},
+				Tooltip: T("settings.volume_up"),
+				OnClick: step(VolumeStep),
+			},
+			Sprite: Sprites[SpriteButtonSmall],
+			Text:   "+",
+		}
+		label := &Label{Base: Base{Layout: Layout{X: 8 + TileSize*2 + 6, Y: y, W: 124, H: TileSize}}}
+		volumeLabels = append(volumeLabels, label)
+		panel.Widgets = append(panel.Widgets, down, up, label)
+	}
+
+	closeButton := &Button{
+		Base: Base{
+			Layout:  Layout{Anchor: AnchorTopRight, X: -4, Y: 4, W: TileSize, H: TileSize},
+			Tooltip: T("settings.close"),
+			OnClick: func() { g.Scenes.Pop(g) },
+		},
+		Sprite: Sprites[SpriteButtonSmall],
+		Text:   "x",
+	}
+	panel.Widgets = append(panel.Widgets, closeButton)
+
+	s.ui = NewUI(panel)
+	s.ui.Refresh = func() {
+		for i, box := range boxes {
+			box.Sprite = Sprites[SpriteCheckboxOff]
+			if *settingToggles[i].Value(&g.Settings) {
+				box.Sprite = Sprites[SpriteCheckboxOn]
+			}
+		}
+		for i, label := range volumeLabels {
+			percent := int(math.Round(*volumeSettings[i].Value(&g.Settings) * 100))
+			label.Text = T(volumeSettings[i].Label, "percent", percent)
+		}
+	}
+}
+
+func (s *SettingsScene) Exit(g *Game) {}
+
+func (s *SettingsScene) Overlay() bool { return true }
+
+func (s *SettingsScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.Scenes.Pop(g)
+		return
+	}
+	s.ui.Update()
+}
+
+func (s *SettingsScene) Draw(g *Game, screen *ebiten.Image) {
+	vector.FillRect(screen, 0, 0, LayoutWidth, LayoutHeight, dimColor, false)
+	s.ui.Draw(g, screen)
+}
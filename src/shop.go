@@ -1,5 +1,38 @@
 package main
 
+import "slices"
+
+type ShopItem struct {
+	Card  Card
+	Relic Relic // RelicNone for card items
+	Price int
+}
+
 type Shop struct {
-	items []Card
-}
\ No newline at end of file
+	items []ShopItem
+}
+
+// Buy refuses items the player cannot afford and relics they already own.
+func (g *Game) Buy(i int) bool {
+	item := g.Shop.items[i]
+	if g.Gold < item.Price || (item.Relic != RelicNone && g.HasRelic(item.Relic)) {
+		return false
+	}
+	g.Gold -= item.Price
+
+	if item.Relic != RelicNone {
+		g.GrantRelic(item.Relic)
+	} else {
+		g.AddToDeck(item.Card)
+		g.Shop.items = slices.Delete(g.Shop.items, i, i+1)
+	}
+	return true
+}
+
+// GrantRelic gives the player a relic and takes it off sale in the shop.
+func (g *Game) GrantRelic(relic Relic) {
+	g.Relics = append(g.Relics, relic)
+	g.Shop.items = slices.DeleteFunc(g.Shop.items, func(item ShopItem) bool {
+		return item.Relic == relic
+	})
+}
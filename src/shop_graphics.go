@@ -1,15 +1,89 @@
 package main
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
 
-func (g *Game) DrawShop(screen *ebiten.Image) {
-	shop := g.Shop
+type ShopScene struct {
+	ui *UI
+}
+
+func (s *ShopScene) Enter(g *Game) {
+	s.Localize(g)
+}
+
+func (s *ShopScene) Exit(g *Game) {}
+
+func (s *ShopScene) Overlay() bool { return false }
+
+func (s *ShopScene) Music() Track { return TrackShop }
+
+func (s *ShopScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.Scenes.Pop(g)
+		return
+	}
+	s.ui.Update()
+}
 
-	for i, item := range shop.items {
-		opt := g.Graphics.Position(10, 10+float64(i)*TileSize)
+func (s *ShopScene) Draw(g *Game, screen *ebiten.Image) {
+	g.DrawRelicBar(screen)
+	s.ui.Draw(g, screen)
+}
 
-		spriteId := TileToSprite[White][item.Piece]
+// Localize lays out one row per item for sale. Rows are rebuilt after every
+// purchase, since buying removes the item.
+func (s *ShopScene) Localize(g *Game) {
+	list := &ScrollList{
+		Base:       Base{Layout: Layout{X: 10, Y: 10, W: 120, H: TileSize * 12}},
+		ItemHeight: TileSize,
+	}
+	for i, item := range g.Shop.items {
+		var icon Widget
+		tooltip := item.Card.Name()
+		if item.Relic != RelicNone {
+			hooks := relics[item.Relic]
+			icon = &Button{
+				Base:   Base{Layout: Layout{W: TileSize, H: TileSize}},
+				Sprite: Sprites[SpriteButtonSmall],
+				Text:   hooks.Symbol,
+			}
+			tooltip = T(hooks.Name) + ": " + T(hooks.Description)
+		} else {
+			icon = &CardSlot{Base: Base{Layout: Layout{W: TileSize, H: TileSize}}, Card: item.Card}
+		}
+		price := &Label{
+			Base: Base{Layout: Layout{X: TileSize + 4, W: 40, H: TileSize}},
+			Text: T("shop.price", "price", item.Price),
+		}
+
+		owned := item.Relic != RelicNone && g.HasRelic(item.Relic)
+		switch {
+		case owned:
+			tooltip += " " + T("shop.owned")
+		case g.Gold < item.Price:
+			tooltip += " " + T("shop.need", "price", item.Price)
+		}
+
+		row := &Panel{
+			Base: Base{
+				Tooltip:  tooltip,
+				Disabled: owned || g.Gold < item.Price,
+				OnClick: func() {
+					if g.Buy(i) {
+						s.Localize(g)
+					}
+				},
+			},
+			Widgets: []Widget{icon, price},
+		}
+		list.Items = append(list.Items, row)
+	}
 
-		screen.DrawImage(Sprites[spriteId], &opt)
+	hint := &Label{
+		Base: Base{Layout: Layout{Anchor: AnchorTopRight, X: -8, Y: 8, W: 7 * 11, H: TileSize}},
+		Text: T("shop.back"),
 	}
+	s.ui = NewUI(list, hint)
 }
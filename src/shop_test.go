@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestGrantRelicTakesItOffSale(t *testing.T) {
+	g := &Game{Gold: 10}
+	g.Shop.items = []ShopItem{{Relic: RelicBounty, Price: 6}}
+
+	g.GrantRelic(RelicBounty)
+	if len(g.Shop.items) != 0 {
+		t.Errorf("shop items = %+v, want none", g.Shop.items)
+	}
+
+	g.Shop.items = []ShopItem{{Relic: RelicBounty, Price: 6}}
+	if g.Buy(0) {
+		t.Error("Buy() of an owned relic succeeded")
+	}
+	if g.Gold != 10 || len(g.Relics) != 1 {
+		t.Errorf("gold = %d, relics = %v, want 10 gold and one relic", g.Gold, g.Relics)
+	}
+}
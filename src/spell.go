@@ -0,0 +1,129 @@
+package main
+
+type Spell int
+
+const (
+	SpellNone Spell = iota
+	SpellSwap
+	SpellPromote
+	SpellFreeze
+	SpellWall
+)
+
+type TargetRule int
+
+const (
+	TargetFriendlyPiece TargetRule = iota
+	TargetFriendlyPawn
+	TargetEnemyPiece
+	TargetEmptySquare
+)
+
+func (rule TargetRule) Allows(board *Board, pos Position) bool {
+	tile := board.Tiles[pos.Y][pos.X]
+	switch rule {
+	case TargetFriendlyPiece:
+		return tile.Piece != PieceEmpty && tile.Color == White
+	case TargetFriendlyPawn:
+		return tile.Piece == PiecePawn && tile.Color == White
+	case TargetEnemyPiece:
+		return tile.Piece != PieceEmpty && tile.Color == Black
+	case TargetEmptySquare:
+		return tile.Piece == PieceEmpty && !board.isWall(pos.X, pos.Y)
+	}
+	return false
+}
+
+// CardEffect is what a non-unit card does once all of its targets have been
+// picked on the board. MaxLevel is the highest card level the effect gets
+// stronger with, so cards of effects that ignore their level never merge.
+type CardEffect interface {
+	Name() string
+	Targets() []TargetRule
+	MaxLevel() int
+	Apply(board *Board, targets []Position, level int)
+}
+
+var cardEffects = map[Spell]CardEffect{
+	SpellSwap:    swapEffect{},
+	SpellPromote: promoteEffect{},
+	SpellFreeze:  freezeEffect{},
+	SpellWall:    wallEffect{},
+}
+
+type swapEffect struct{}
+
+func (swapEffect) Name() string {
+	return "spell.swap"
+}
+
+func (swapEffect) Targets() []TargetRule {
+	return []TargetRule{TargetFriendlyPiece, TargetFriendlyPiece}
+}
+
+func (swapEffect) MaxLevel() int {
+	return 0
+}
+
+func (swapEffect) Apply(board *Board, targets []Position, level int) {
+	a, b := targets[0], targets[1]
+	board.Tiles[a.Y][a.X], board.Tiles[b.Y][b.X] = board.Tiles[b.Y][b.X], board.Tiles[a.Y][a.X]
+}
+
+type promoteEffect struct{}
+
+func (promoteEffect) Name() string {
+	return "spell.promote"
+}
+
+func (promoteEffect) Targets() []TargetRule {
+	return []TargetRule{TargetFriendlyPawn}
+}
+
+func (promoteEffect) MaxLevel() int {
+	return 0
+}
+
+func (promoteEffect) Apply(board *Board, targets []Position, level int) {
+	pos := targets[0]
+	board.Tiles[pos.Y][pos.X].Piece = PieceQueen
+}
+
+type freezeEffect struct{}
+
+func (freezeEffect) Name() string {
+	return "spell.freeze"
+}
+
+func (freezeEffect) Targets() []TargetRule {
+	return []TargetRule{TargetEnemyPiece}
+}
+
+func (freezeEffect) MaxLevel() int {
+	return MaxCardLevel
+}
+
+func (freezeEffect) Apply(board *Board, targets []Position, level int) {
+	pos := targets[0]
+	board.Tiles[pos.Y][pos.X].Frozen += FreezeTurns + level
+}
+
+type wallEffect struct{}
+
+func (wallEffect) Name() string {
+	return "spell.wall"
+}
+
+func (wallEffect) Targets() []TargetRule {
+	return []TargetRule{TargetEmptySquare}
+}
+
+func (wallEffect) MaxLevel() int {
+	return MaxCardLevel
+}
+
+func (wallEffect) Apply(board *Board, targets []Position, level int) {
+	pos := targets[0]
+	board.Terrain[pos.Y][pos.X] = TerrainWall
+	board.TerrainTimer[pos.Y][pos.X] = (WallTurns + level) * 2
+}
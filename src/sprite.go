@@ -1,11 +1,12 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"image"
-	"log"
+	"io/fs"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 type SpriteID int
@@ -28,28 +29,48 @@ const (
 	SpriteKnightWhite
 	SpritePawnWhite
 	SpritePlayButton
+	SpriteButtonSmall
+	SpriteIconPlay
+	SpriteIconPause
+	SpriteIconRestart
+	SpriteIconGear
+	SpriteCheckboxOff
+	SpriteCheckboxOn
 )
 
-const t = TileSize
-
-var atlas = map[SpriteID]image.Rectangle{
-	SpriteBoard:       image.Rect(t*0, t*0, t*10, t*9),
-	SpriteTileWhite:   image.Rect(t*1, t*12, t*2, t*13),
-	SpriteTileBlack:   image.Rect(t*2, t*12, t*3, t*13),
-	SpriteHover:       image.Rect(t*3, t*12, t*4, t*13),
-	SpriteKingBlack:   image.Rect(t*1, t*13, t*2, t*14),
-	SpriteQueenBlack:  image.Rect(t*2, t*13, t*3, t*14),
-	SpriteRookBlack:   image.Rect(t*3, t*13, t*4, t*14),
-	SpriteBishopBlack: image.Rect(t*4, t*13, t*5, t*14),
-	SpriteKnightBlack: image.Rect(t*5, t*13, t*6, t*14),
-	SpritePawnBlack:   image.Rect(t*6, t*13, t*7, t*14),
-	SpriteKingWhite:   image.Rect(t*1, t*14, t*2, t*15),
-	SpriteQueenWhite:  image.Rect(t*2, t*14, t*3, t*15),
-	SpriteRookWhite:   image.Rect(t*3, t*14, t*4, t*15),
-	SpriteBishopWhite: image.Rect(t*4, t*14, t*5, t*15),
-	SpriteKnightWhite: image.Rect(t*5, t*14, t*6, t*15),
-	SpritePawnWhite:   image.Rect(t*6, t*14, t*7, t*15),
-	SpritePlayButton:  image.Rect(t*17, t*10, t*20, t*11),
+// spriteNames maps each sprite to its entry in the sprite manifest.
+var spriteNames = map[SpriteID]string{
+	SpriteBoard:       "board",
+	SpriteTileWhite:   "tile_white",
+	SpriteTileBlack:   "tile_black",
+	SpriteHover:       "hover",
+	SpriteKingBlack:   "king_black",
+	SpriteQueenBlack:  "queen_black",
+	SpriteRookBlack:   "rook_black",
+	SpriteBishopBlack: "bishop_black",
+	SpriteKnightBlack: "knight_black",
+	SpritePawnBlack:   "pawn_black",
+	SpriteKingWhite:   "king_white",
+	SpriteQueenWhite:  "queen_white",
+	SpriteRookWhite:   "rook_white",
+	SpriteBishopWhite: "bishop_white",
+	SpriteKnightWhite: "knight_white",
+	SpritePawnWhite:   "pawn_white",
+	SpritePlayButton:  "play_button",
+	SpriteButtonSmall: "button_small",
+	SpriteIconPlay:    "icon_play",
+	SpriteIconPause:   "icon_pause",
+	SpriteIconRestart: "icon_restart",
+	SpriteIconGear:    "icon_gear",
+	SpriteCheckboxOff: "checkbox_off",
+	SpriteCheckboxOn:  "checkbox_on",
+}
+
+var SpellToSprite = map[Spell]SpriteID{
+	SpellSwap:    SpriteIconRestart,
+	SpellPromote: SpriteQueenWhite,
+	SpellFreeze:  SpriteIconPause,
+	SpellWall:    SpriteTileBlack,
 }
 
 var TileToSprite = map[Color]map[Piece]SpriteID{
@@ -77,15 +98,155 @@ var PieceToBlackSprite = map[Piece]SpriteID{
 
 var Sprites map[SpriteID]*ebiten.Image
 
-func init() {
-	imgAtlas, _, err := ebitenutil.NewImageFromFile(SpriteAtlasPath)
+// SpriteFrame is one frame of an animation clip. Frames without art of their
+// own reuse the static sprite, moved by Offset and tinted.
+type SpriteFrame struct {
+	Image    *ebiten.Image
+	Duration float64     // seconds
+	Offset   image.Point // negative Y leans toward the enemy
+	Tint     ebiten.ColorScale
+}
+
+// SpriteClips holds the animation clips of every piece sprite.
+var SpriteClips map[SpriteID]map[Clip][]SpriteFrame
+
+const SpriteManifestPath = "sprites.json"
+
+// spriteManifest lists the atlas regions of every sprite. Clips at the top
+// level are the fallbacks for piece sprites that have no frames of their own.
+type spriteManifest struct {
+	Atlas   string                  `json:"atlas"`
+	Sprites map[string]spriteEntry  `json:"sprites"`
+	Clips   map[string][]frameEntry `json:"clips"`
+}
+
+// spriteEntry is a region of the atlas as [x, y, width, height] in pixels,
+// optionally with animation clips.
+type spriteEntry struct {
+	Rect  [4]int                  `json:"rect"`
+	Clips map[string][]frameEntry `json:"clips"`
+}
+
+// frameEntry is one frame of a clip. A frame without a rect shows the
+// sprite's own region.
+type frameEntry struct {
+	Rect     [4]int    `json:"rect"`
+	Duration float64   `json:"duration"`
+	Offset   [2]int    `json:"offset"`
+	Tint     []float32 `json:"tint"`  // red, green and blue scale
+	Alpha    *float32  `json:"alpha"` // opaque when omitted
+}
+
+func atlasRect(r [4]int) image.Rectangle {
+	return image.Rect(r[0], r[1], r[0]+r[2], r[1]+r[3])
+}
+
+// Validate checks that the manifest has every sprite the game uses, that
+// every region lies within an atlas of the given bounds and that every clip
+// frame lasts some time.
+func (manifest spriteManifest) Validate(bounds image.Rectangle) error {
+	// owner names the sprite, or the fallback clips, in error messages
+	checkRect := func(owner string, r [4]int) error {
+		if rect := atlasRect(r); rect.Empty() || !rect.In(bounds) {
+			return fmt.Errorf("%s: %s region %v is outside the atlas", SpriteManifestPath, owner, r)
+		}
+		return nil
+	}
+	checkClips := func(owner string, clips map[string][]frameEntry) error {
+		for clipName, frames := range clips {
+			for _, entry := range frames {
+				if entry.Duration <= 0 {
+					return fmt.Errorf("%s: %s clip %q has a frame without a duration", SpriteManifestPath, owner, clipName)
+				}
+				if entry.Rect[2] > 0 {
+					if err := checkRect(owner, entry.Rect); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, name := range spriteNames {
+		entry, ok := manifest.Sprites[name]
+		if !ok {
+			return fmt.Errorf("%s: missing sprite %q", SpriteManifestPath, name)
+		}
+		owner := fmt.Sprintf("sprite %q", name)
+		if err := checkRect(owner, entry.Rect); err != nil {
+			return err
+		}
+		if err := checkClips(owner, entry.Clips); err != nil {
+			return err
+		}
+	}
+	return checkClips("fallback", manifest.Clips)
+}
+
+// LoadSprites reads the sprite manifest and its atlas from fsys and cuts out
+// every sprite the game uses.
+func LoadSprites(fsys fs.FS) error {
+	data, err := fs.ReadFile(fsys, SpriteManifestPath)
+	if err != nil {
+		return err
+	}
+	manifest := spriteManifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("%s: %w", SpriteManifestPath, err)
+	}
+
+	file, err := fsys.Open(manifest.Atlas)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoded, _, err := image.Decode(file)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("%s: %w", manifest.Atlas, err)
+	}
+	if err := manifest.Validate(decoded.Bounds()); err != nil {
+		return err
+	}
+	imgAtlas := ebiten.NewImageFromImage(decoded)
+	cut := func(r [4]int) *ebiten.Image {
+		return imgAtlas.SubImage(atlasRect(r)).(*ebiten.Image)
 	}
 
 	Sprites = make(map[SpriteID]*ebiten.Image)
+	for id, name := range spriteNames {
+		Sprites[id] = cut(manifest.Sprites[name].Rect)
+	}
 
-	for id, rect := range atlas {
-		Sprites[id] = imgAtlas.SubImage(rect).(*ebiten.Image)
+	SpriteClips = make(map[SpriteID]map[Clip][]SpriteFrame)
+	for _, pieces := range TileToSprite {
+		for _, id := range pieces {
+			name := spriteNames[id]
+			SpriteClips[id] = make(map[Clip][]SpriteFrame)
+			for clip, clipName := range clipNames {
+				frames, ok := manifest.Sprites[name].Clips[clipName]
+				if !ok {
+					frames = manifest.Clips[clipName]
+				}
+				for _, entry := range frames {
+					frame := SpriteFrame{
+						Image:    Sprites[id],
+						Duration: entry.Duration,
+						Offset:   image.Pt(entry.Offset[0], entry.Offset[1]),
+					}
+					if entry.Rect[2] > 0 {
+						frame.Image = cut(entry.Rect)
+					}
+					if len(entry.Tint) == 3 {
+						frame.Tint.Scale(entry.Tint[0], entry.Tint[1], entry.Tint[2], 1)
+					}
+					if entry.Alpha != nil {
+						frame.Tint.ScaleAlpha(*entry.Alpha)
+					}
+					SpriteClips[id][clip] = append(SpriteClips[id][clip], frame)
+				}
+			}
+		}
 	}
+	return nil
 }
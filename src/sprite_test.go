@@ -0,0 +1,95 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+// validManifest has every sprite the game uses in a 16x16 region of a
+// 16x16 atlas.
+func validManifest() spriteManifest {
+	manifest := spriteManifest{Sprites: map[string]spriteEntry{}}
+	for _, name := range spriteNames {
+		manifest.Sprites[name] = spriteEntry{Rect: [4]int{0, 0, 16, 16}}
+	}
+	return manifest
+}
+
+func TestSpriteManifestValidate(t *testing.T) {
+	bounds := image.Rect(0, 0, 16, 16)
+
+	tests := []struct {
+		name   string
+		modify func(manifest *spriteManifest)
+		valid  bool
+	}{
+		{
+			name:   "valid",
+			modify: func(manifest *spriteManifest) {},
+			valid:  true,
+		},
+		{
+			name: "missing sprite",
+			modify: func(manifest *spriteManifest) {
+				delete(manifest.Sprites, spriteNames[SpriteBoard])
+			},
+		},
+		{
+			name: "region outside the atlas",
+			modify: func(manifest *spriteManifest) {
+				manifest.Sprites[spriteNames[SpriteBoard]] = spriteEntry{Rect: [4]int{8, 8, 16, 16}}
+			},
+		},
+		{
+			name: "empty region",
+			modify: func(manifest *spriteManifest) {
+				manifest.Sprites[spriteNames[SpriteBoard]] = spriteEntry{}
+			},
+		},
+		{
+			name: "clip frame",
+			modify: func(manifest *spriteManifest) {
+				manifest.Sprites[spriteNames[SpriteBoard]] = spriteEntry{
+					Rect:  [4]int{0, 0, 16, 16},
+					Clips: map[string][]frameEntry{"idle": {{Rect: [4]int{0, 0, 8, 8}, Duration: 0.1}}},
+				}
+			},
+			valid: true,
+		},
+		{
+			name: "clip frame without a duration",
+			modify: func(manifest *spriteManifest) {
+				manifest.Sprites[spriteNames[SpriteBoard]] = spriteEntry{
+					Rect:  [4]int{0, 0, 16, 16},
+					Clips: map[string][]frameEntry{"idle": {{}}},
+				}
+			},
+		},
+		{
+			name: "clip frame outside the atlas",
+			modify: func(manifest *spriteManifest) {
+				manifest.Sprites[spriteNames[SpriteBoard]] = spriteEntry{
+					Rect:  [4]int{0, 0, 16, 16},
+					Clips: map[string][]frameEntry{"idle": {{Rect: [4]int{12, 0, 8, 8}, Duration: 0.1}}},
+				}
+			},
+		},
+		{
+			name: "fallback clip without a duration",
+			modify: func(manifest *spriteManifest) {
+				manifest.Clips = map[string][]frameEntry{"idle": {{}}}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifest := validManifest()
+			test.modify(&manifest)
+			err := manifest.Validate(bounds)
+			if (err == nil) != test.valid {
+				t.Errorf("Validate() = %v, want valid %t", err, test.valid)
+			}
+		})
+	}
+}
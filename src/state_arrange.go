@@ -1,15 +1,83 @@
 package main
 
 import (
-	"slices"
-
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
+type ArrangeScene struct {
+	ui *UI
+}
+
+func (s *ArrangeScene) Enter(g *Game) {
+	s.Localize(g)
+}
+
+func (s *ArrangeScene) Localize(g *Game) {
+	hand := g.NewHandList()
+	inspector := NewInspectorPanel()
+	play := NewPlayButton(func() { g.Scenes.Replace(g, &PlayScene{}) })
+	s.ui = NewUI(hand, inspector, play, NewSettingsButton(g))
+	s.ui.Refresh = func() {
+		g.SyncHandList(hand, true)
+		inspector.Refresh(g)
+		play.Disabled = g.Objective.NeedsVIP() && len(g.Board.findKings(White)) == 0
+		play.Tooltip = T("control.play")
+		if play.Disabled {
+			play.Tooltip = T("control.need_vip")
+		}
+	}
+}
+
+func (s *ArrangeScene) Exit(g *Game) {
+	g.Drag = Drag{}
+	g.Forecast.Stop()
+	g.Optimizer.Stop()
+}
+
+func (s *ArrangeScene) Overlay() bool { return false }
+
+func (s *ArrangeScene) Music() Track { return TrackArrange }
+
+func (s *ArrangeScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		// the shop keeps the arrangement, but there is no point in
+		// forecasting it while the player shops, and purchases can merge
+		// the cards a suggestion was made for
+		g.Forecast.Stop()
+		g.Optimizer.Stop()
+		g.Scenes.Push(g, &ShopScene{})
+		return
+	}
+	s.ui.Update()
+	if g.Scenes.Top() != s {
+		return
+	}
+	g.UpdateHover()
+	g.UpdateStateArrange()
+}
+
+func (s *ArrangeScene) Draw(g *Game, screen *ebiten.Image) {
+	g.DrawBoard(screen)
+	g.DrawOverlays(screen)
+	g.DrawAnimations(screen)
+	g.Effects.DrawParticles(screen)
+	g.DrawSpellTargets(screen)
+	g.DrawSynergies(screen)
+	g.DrawForecast(screen)
+	g.DrawOptimizer(screen)
+	g.DrawObjective(screen)
+	g.DrawRelicBar(screen)
+	s.ui.Draw(g, screen)
+	g.DrawDrag(screen)
+	g.DrawPieceTooltip(screen)
+}
+
 func (g *Game) UpdateStateArrange() {
-	g.UpdateHand()
-	g.UpdateControl()
+	g.UpdateForecast()
+	g.UpdateOptimizer()
+
+	g.UpdateDrag()
 
 	graphicsBoard := &g.Graphics.Board
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
@@ -20,6 +88,12 @@ func (g *Game) UpdateStateArrange() {
 		x, y := ebiten.CursorPosition()
 		handleRightClick(g, graphicsBoard, x, y)
 	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+		mx, my := ebiten.CursorPosition()
+		if x, y, ok := ScreenToTile(graphicsBoard, mx, my); ok {
+			g.Board.DesignateLeader(Position{X: x, Y: y})
+		}
+	}
 }
 
 func handleLeftClick(game *Game, board *GraphicsBoard, x, y int) {
@@ -27,12 +101,23 @@ func handleLeftClick(game *Game, board *GraphicsBoard, x, y int) {
 	if !ok {
 		return
 	}
-
-	card := game.Hand.Cards[game.Hand.SelectIndex]
-	game.Board.Tiles[y][x] = Tile{Piece: card.Piece, Color: White}
-
-	game.Hand.SelectIndex = 0
-	game.Hand.Cards = slices.Delete(game.Hand.Cards, game.Hand.SelectIndex, game.Hand.SelectIndex+1)
+	card, ok := game.Hand.Selected()
+	if ok && card.IsSpell() {
+		game.TargetSpell(Position{X: x, Y: y})
+		return
+	}
+	if !ok || !game.Board.canPlace(x, y) {
+		if game.Board.Tiles[y][x].Color == White {
+			game.DragPiece(Position{X: x, Y: y})
+		} else {
+			game.Overlays.Select(Position{X: x, Y: y})
+		}
+		return
+	}
+	tile := Tile{Piece: card.Piece, Color: White, Level: card.Level, Unit: card.Unit}
+	game.Board.Tiles[y][x] = tile
+	game.Hand.Remove(game.Hand.SelectIndex)
+	game.Events.Emit(Event{Kind: EventPlace, Tile: tile, Position: Position{X: x, Y: y}})
 }
 
 func handleRightClick(game *Game, board *GraphicsBoard, x, y int) {
@@ -40,6 +125,10 @@ func handleRightClick(game *Game, board *GraphicsBoard, x, y int) {
 	if !ok {
 		return
 	}
-	game.Board.Tiles[y][x].Piece = PieceEmpty
-	board.ShakeDuration = 5
+	removed := game.Board.Tiles[y][x]
+	if removed.Piece == PieceEmpty || removed.Color != White {
+		return
+	}
+	game.Board.Tiles[y][x] = Tile{Piece: PieceEmpty}
+	game.Events.Emit(Event{Kind: EventRemove, Tile: removed, Position: Position{X: x, Y: y}})
 }
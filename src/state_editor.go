@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type BrushKind int
+
+const (
+	BrushPiece BrushKind = iota
+	BrushErase
+	BrushWall
+)
+
+type Brush struct {
+	Kind  BrushKind
+	Piece Piece
+	Color Color
+}
+
+var editorPalette = []Brush{
+	{Kind: BrushPiece, Piece: PiecePawn, Color: White},
+	{Kind: BrushPiece, Piece: PieceKnight, Color: White},
+	{Kind: BrushPiece, Piece: PieceBishop, Color: White},
+	{Kind: BrushPiece, Piece: PieceRook, Color: White},
+	{Kind: BrushPiece, Piece: PieceQueen, Color: White},
+	{Kind: BrushPiece, Piece: PieceKing, Color: White},
+	{Kind: BrushErase},
+	{Kind: BrushPiece, Piece: PiecePawn, Color: Black},
+	{Kind: BrushPiece, Piece: PieceKnight, Color: Black},
+	{Kind: BrushPiece, Piece: PieceBishop, Color: Black},
+	{Kind: BrushPiece, Piece: PieceRook, Color: Black},
+	{Kind: BrushPiece, Piece: PieceQueen, Color: Black},
+	{Kind: BrushPiece, Piece: PieceKing, Color: Black},
+	{Kind: BrushWall},
+}
+
+type Editor struct {
+	BrushIndex int
+	Slot       int
+	Message    string
+}
+
+type EditorScene struct {
+	ui *UI
+}
+
+func (s *EditorScene) Enter(g *Game) {
+	g.Editor.Slot = g.MatchIndex
+	g.Editor.Message = ""
+	s.Localize(g)
+}
+
+func (s *EditorScene) Localize(g *Game) {
+	playtest := NewPlayButton(func() {
+		if err := g.Objective.Validate(&g.Board); err != nil {
+			g.Editor.Message = err.Error()
+			return
+		}
+		g.Scenes.Push(g, &PlayScene{Playtest: true})
+	})
+	playtest.Tooltip = T("control.playtest")
+	s.ui = NewUI(playtest)
+
+	var brushes []*Button
+	for i, brush := range editorPalette {
+		x, y := GetPositionForBrush(i)
+		button := &Button{
+			Base: Base{
+				Layout:  Layout{X: int(x), Y: int(y), W: TileSize, H: TileSize},
+				OnClick: func() { g.Editor.BrushIndex = i },
+			},
+			Sprite: Sprites[SpriteTileWhite],
+		}
+		switch brush.Kind {
+		case BrushPiece:
+			button.Icon = Sprites[TileToSprite[brush.Color][brush.Piece]]
+			button.Tooltip = T("editor.brush", "color", T(colorNames[brush.Color]), "piece", PieceName(brush.Piece))
+		case BrushErase:
+			button.Tooltip = T("editor.erase")
+		case BrushWall:
+			button.Sprite = Sprites[SpriteTileBlack]
+			button.Tint.Scale(0.3, 0.3, 0.3, 1)
+			button.Tooltip = T("editor.wall")
+		}
+		brushes = append(brushes, button)
+		s.ui.Add(button)
+	}
+	s.ui.Refresh = func() {
+		for i, button := range brushes {
+			button.Selected = i == g.Editor.BrushIndex
+		}
+	}
+}
+
+func (s *EditorScene) Exit(g *Game) {}
+
+func (s *EditorScene) Overlay() bool { return false }
+
+func (s *EditorScene) Update(g *Game) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		g.Scenes.Replace(g, &MenuScene{})
+		return
+	}
+	s.ui.Update()
+	if g.Scenes.Top() != s {
+		return
+	}
+	g.UpdateStateEditor()
+}
+
+func (s *EditorScene) Draw(g *Game, screen *ebiten.Image) {
+	g.DrawBoard(screen)
+	g.DrawEditor(screen)
+	s.ui.Draw(g, screen)
+}
+
+func (g *Game) UpdateStateEditor() {
+	editor := &g.Editor
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		if x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my); ok {
+			g.Board.Paint(x, y, editorPalette[editor.BrushIndex])
+		}
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		mx, my := ebiten.CursorPosition()
+		if x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my); ok {
+			tile := &g.Board.Tiles[y][x]
+			if tile.Piece != PieceEmpty {
+				tile.King = !tile.King
+			}
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		mx, my := ebiten.CursorPosition()
+		if x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my); ok {
+			g.Objective.Target = Position{X: x, Y: y}
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft) {
+		editor.Slot = max(editor.Slot-1, 0)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketRight) {
+		editor.Slot += 1
+	}
+	minTurns := 0
+	if g.Objective.UsesTurns() {
+		minTurns = 1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyO) {
+		g.Objective.Kind = (g.Objective.Kind + 1) % objectiveKindCount
+		if g.Objective.UsesTurns() {
+			g.Objective.Turns = max(g.Objective.Turns, 1)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		g.Objective.Turns = min(g.Objective.Turns+1, MaxBattleTurns)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		g.Objective.Turns = max(g.Objective.Turns-1, minTurns)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		path := filepath.Join(g.MatchDir, MatchFileName(editor.Slot))
+		if g.MatchDir == "" {
+			editor.Message = T("editor.no_match_dir")
+		} else if err := SaveMatchFile(path, &g.Board, g.Objective); err != nil {
+			editor.Message = err.Error()
+		} else {
+			editor.Message = T("editor.saved", "path", path)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		board, objective, err := g.LoadMatch(editor.Slot)
+		if err != nil {
+			editor.Message = err.Error()
+		} else {
+			g.Board = board
+			g.Objective = objective
+			editor.Message = T("editor.loaded", "path", MatchFileName(editor.Slot))
+		}
+	}
+}
+
+func (board *Board) Paint(x, y int, brush Brush) {
+	switch brush.Kind {
+	case BrushPiece:
+		board.Tiles[y][x] = Tile{Piece: brush.Piece, Color: brush.Color}
+		board.Terrain[y][x] = TerrainFloor
+	case BrushErase:
+		board.Tiles[y][x] = Tile{Piece: PieceEmpty}
+		board.Terrain[y][x] = TerrainFloor
+	case BrushWall:
+		board.Tiles[y][x] = Tile{Piece: PieceEmpty}
+		board.Terrain[y][x] = TerrainWall
+	}
+}
+
+func (g *Game) DrawEditor(screen *ebiten.Image) {
+	turns := T("editor.no_limit")
+	if g.Objective.Turns > 0 {
+		turns = T("editor.turns", "count", g.Objective.Turns)
+	}
+	g.Graphics.DrawText(screen, T("editor.help", "slot", fmt.Sprintf("%02d", g.Editor.Slot)), 8, 160)
+	g.Graphics.DrawText(screen, T("editor.objective", "objective", g.Objective.Name(), "turns", turns, "target", g.Objective.Target), 8, 174)
+	g.Graphics.DrawText(screen, g.Editor.Message, 8, 188)
+}
+
+func GetPositionForBrush(i int) (float64, float64) {
+	x := float64(TileSize/2 + (i/7)*TileSize)
+	y := float64(TileSize + (i%7)*TileSize)
+	return x, y
+}
@@ -1,32 +1,182 @@
 package main
 
 import (
-	"time"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
-func (g *Game) UpdateStatePlay() {
-	now := time.Now()
-	if now.Sub(g.PrevComputerTime).Seconds() < (1 / ComputerFPS) {
+// PlayScene runs a battle. A playtest battle restores the edited board and
+// returns to the editor when it ends or is stopped with F2. A battle left
+// unfinished, such as by quitting to the main menu, puts the board back as
+// it was arranged.
+type PlayScene struct {
+	Playtest bool
+	saved    Board
+	finished bool
+	ui       *UI
+	controls *BattleControls
+}
+
+func (s *PlayScene) Enter(g *Game) {
+	s.Localize(g)
+	s.saved = g.Board
+	// synergies and relics are settled for the whole battle
+	g.Board.Rules[White] = g.PlayerRules()
+	g.BattleCaptures = 0
+	g.Deployed = g.Board.deployedUnits()
+	g.Intent = Intent{}
+	g.Casualties = nil
+	g.History = nil
+	g.Paused = false
+	g.Events.Emit(Event{Kind: EventBattleStart})
+}
+
+func (s *PlayScene) Localize(g *Game) {
+	hand := g.NewHandList()
+	inspector := NewInspectorPanel()
+	s.controls = NewBattleControls()
+	s.ui = NewUI(hand, inspector, s.controls.Panel, NewSettingsButton(g))
+	if s.Playtest {
+		s.ui.Add(NewMenuButton(T("control.stop_playtest"), AnchorTop, 220, func() { g.Scenes.Pop(g) }))
+	}
+	s.ui.Refresh = func() {
+		g.SyncHandList(hand, false)
+		inspector.Refresh(g)
+		s.controls.Refresh(g)
+	}
+}
+
+func (s *PlayScene) Exit(g *Game) {
+	if s.Playtest || !s.finished {
+		g.Board = s.saved
+		g.Animator.Clear()
+		g.Overlays.Clear()
+	}
+}
+
+func (s *PlayScene) Overlay() bool { return false }
+
+func (s *PlayScene) Music() Track { return TrackBattle }
+
+func (s *PlayScene) Update(g *Game) {
+	if s.Playtest && inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		g.Scenes.Pop(g)
+		return
+	}
+	s.ui.Update()
+	if g.Scenes.Top() != s {
+		return
+	}
+	status := g.UpdateStatePlay(s.controls)
+	if status == ObjectivePending {
 		return
 	}
 
-	g.PrevComputerTime = now
+	won := status == ObjectiveWon
+	s.finished = true
+	g.Events.Emit(Event{Kind: EventBattleEnd, Won: won})
+	// deliver the final move while its board is still the one on screen;
+	// the next match or the restored board clears what it left behind
+	g.Events.Flush()
+	if s.Playtest {
+		g.Scenes.Pop(g)
+		return
+	}
+	g.Scenes.Replace(g, &ArrangeScene{})
+	if run := g.EndBattle(won); run != ObjectivePending {
+		g.Scenes.Reset(g, &RunSummaryScene{Won: run == ObjectiveWon})
+	}
+}
+
+func (s *PlayScene) Draw(g *Game, screen *ebiten.Image) {
+	g.DrawBoard(screen)
+	g.DrawOverlays(screen)
+	g.DrawAnimations(screen)
+	g.Effects.DrawParticles(screen)
+	g.DrawIntent(screen)
+	g.DrawSpellTargets(screen)
+	g.DrawBattleSpeed(screen)
+	g.DrawObjective(screen)
+	g.DrawRelicBar(screen)
+	s.ui.Draw(g, screen)
+	g.DrawPieceTooltip(screen)
+}
+
+func (g *Game) UpdateStatePlay(controls *BattleControls) ObjectiveStatus {
+	g.UpdateIntent()
+	g.UpdateHover()
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		mx, my := ebiten.CursorPosition()
+		if x, y, ok := ScreenToTile(&g.Graphics.Board, mx, my); ok {
+			if card, ok := g.Hand.Selected(); ok && card.IsSpell() {
+				g.TargetSpell(Position{X: x, Y: y})
+			} else {
+				g.Overlays.Select(Position{X: x, Y: y})
+			}
+		}
+	}
+
+	step := g.UpdateBattleSpeed(controls)
+	if !step && g.Speed == SpeedInstant && !g.Paused {
+		return g.playInstant()
+	}
+	if !step && !g.plyDue() {
+		return ObjectivePending
+	}
+	return g.PlayPly()
+}
+
+// PlayPly lets the side to move make one move and checks the objective.
+func (g *Game) PlayPly() ObjectiveStatus {
+	g.History = append(g.History, g.Board)
 	board := &g.Board
-	move, ok := ComputeMove(board, 6)
+	line, ok := ComputeLine(board, 6)
 
 	if ok {
-		target := board.Tiles[move.To.Y][move.To.X]
-		ApplyMove(board, move)
-
-		if target.King {
-			g.EndStatePlay()
+		move := line[0]
+		g.Intent = predictIntent(*board, line)
+		captured := g.PlayMove(move).Captured
+		if captured.Piece != PieceEmpty && captured.Color == Black {
+			g.RecordKill(board.Tiles[move.To.Y][move.To.X])
+			g.OnCapture(captured)
+		}
+		if captured.Piece != PieceEmpty && captured.Color == White {
+			g.RecordCasualty(captured)
 		}
+	} else {
+		g.Intent = Intent{}
+		board.PassTurn()
 	}
+
+	return g.Objective.Evaluate(board)
 }
 
-func (g *Game) EndStatePlay() {
-	g.State = StateArrange
+// EndBattle settles the run after a battle and sets up the next match. It
+// returns whether that ended the run.
+func (g *Game) EndBattle(won bool) ObjectiveStatus {
+	g.Stats.Captures += g.BattleCaptures
+	g.Stats.Fallen += len(g.Casualties)
+	if won {
+		g.Stats.Wins += 1
+	} else {
+		g.Stats.Losses += 1
+	}
+	g.SettleUnits()
 	g.AddCardsFromDeckToHand()
-	g.MatchIndex += 1
+	if won {
+		g.OnWin()
+		g.MatchIndex += 1
+	}
 	g.StartMatch(g.MatchIndex)
+	return g.RunStatus()
+}
+
+func (g *Game) DrawObjective(screen *ebiten.Image) {
+	g.Graphics.DrawText(screen, g.Objective.Progress(&g.Board), 8, 160)
+
+	if card, ok := g.Hand.Selected(); ok {
+		if unit, ok := g.Units[card.Unit]; ok {
+			g.Graphics.DrawText(screen, unit.String(), 8, 174)
+		}
+	}
 }
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+)
+
+// Wave returns the level of a waveform at a phase measured in cycles.
+type Wave func(phase float64) float64
+
+func sine(phase float64) float64 {
+	return math.Sin(2 * math.Pi * phase)
+}
+
+func square(phase float64) float64 {
+	if phase-math.Floor(phase) < 0.5 {
+		return 1
+	}
+	return -1
+}
+
+func triangle(phase float64) float64 {
+	return 4*math.Abs(phase-math.Floor(phase)-0.5) - 1
+}
+
+func noise(phase float64) float64 {
+	return rand.Float64()*2 - 1
+}
+
+// Note is a plucked tone. The pitch glides from Freq to Freq*Slide over the
+// note when Slide is set.
+type Note struct {
+	Freq   float64
+	Start  float64 // seconds
+	Length float64 // seconds
+	Volume float64
+	Wave   Wave
+	Slide  float64
+}
+
+// midi converts a MIDI note number to its frequency.
+func midi(note int) float64 {
+	return 440 * math.Pow(2, float64(note-69)/12)
+}
+
+// renderNotes mixes the notes into stereo 32-bit float PCM, the format
+// audio.Context.NewPlayerF32 expects.
+func renderNotes(notes []Note, length float64) []byte {
+	samples := make([]float32, int(length*SampleRate))
+	attack := SampleRate / 200
+	for _, note := range notes {
+		start := int(note.Start * SampleRate)
+		count := int(note.Length * SampleRate)
+		phase := 0.0
+		for i := 0; i < count && start+i < len(samples); i++ {
+			t := float64(i) / float64(count)
+			freq := note.Freq
+			if note.Slide != 0 {
+				freq *= 1 + (note.Slide-1)*t
+			}
+			phase += freq / SampleRate
+
+			envelope := 1 - t
+			if i < attack {
+				envelope = float64(i) / float64(attack)
+			}
+			samples[start+i] += float32(note.Wave(phase) * envelope * note.Volume)
+		}
+	}
+
+	pcm := make([]byte, len(samples)*8)
+	for i, sample := range samples {
+		bits := math.Float32bits(min(max(sample, -1), 1))
+		binary.LittleEndian.PutUint32(pcm[i*8:], bits)
+		binary.LittleEndian.PutUint32(pcm[i*8+4:], bits)
+	}
+	return pcm
+}
+
+// arpeggio plays the notes one after another, each step seconds long.
+func arpeggio(notes []int, step, volume float64, wave Wave) []Note {
+	result := []Note{}
+	for i, note := range notes {
+		result = append(result, Note{Freq: midi(note), Start: float64(i) * step, Length: step * 1.5, Volume: volume, Wave: wave})
+	}
+	return result
+}
+
+// soundNotes describes every sound effect until recorded ones exist.
+var soundNotes = map[Sound][]Note{
+	SoundPlace: {
+		{Freq: 660, Length: 0.08, Volume: 0.4, Wave: sine, Slide: 1.5},
+	},
+	SoundMove: {
+		{Freq: 220, Length: 0.05, Volume: 0.15, Wave: square, Slide: 0.8},
+		{Freq: 1, Length: 0.03, Volume: 0.1, Wave: noise},
+	},
+	SoundCapture: {
+		{Freq: 1, Length: 0.15, Volume: 0.3, Wave: noise},
+		{Freq: 330, Length: 0.2, Volume: 0.3, Wave: square, Slide: 0.4},
+	},
+	SoundPromotion: arpeggio([]int{72, 76, 79, 84}, 0.07, 0.25, square),
+	SoundVictory:   arpeggio([]int{72, 76, 79, 84, 88, 91}, 0.12, 0.25, triangle),
+	SoundDefeat:    arpeggio([]int{67, 63, 60, 55}, 0.25, 0.3, triangle),
+}
+
+// Song is a looping tune in eighth-note melody steps over a bass line of
+// quarter notes. Zero is a rest.
+type Song struct {
+	BPM    float64
+	Lead   Wave
+	Melody []int
+	Bass   []int
+}
+
+var songs = map[Track]Song{
+	TrackArrange: {
+		BPM:  96,
+		Lead: triangle,
+		Melody: []int{
+			72, 0, 76, 79, 77, 0, 76, 74, 72, 0, 74, 76, 74, 0, 0, 0,
+			69, 0, 72, 76, 74, 0, 72, 71, 72, 0, 0, 0, 0, 0, 0, 0,
+		},
+		Bass: []int{48, 48, 55, 55, 53, 53, 55, 55, 45, 45, 52, 52, 53, 55, 48, 48},
+	},
+	TrackBattle: {
+		BPM:  140,
+		Lead: square,
+		Melody: []int{
+			69, 0, 69, 72, 76, 0, 74, 72, 71, 0, 71, 74, 77, 0, 76, 74,
+			72, 0, 76, 0, 81, 0, 79, 77, 76, 74, 72, 71, 69, 0, 0, 0,
+		},
+		Bass: []int{45, 45, 45, 45, 43, 43, 43, 43, 41, 41, 41, 41, 40, 40, 44, 44},
+	},
+	TrackShop: {
+		BPM:  112,
+		Lead: sine,
+		Melody: []int{
+			77, 0, 81, 0, 84, 0, 81, 0, 79, 0, 82, 0, 86, 0, 0, 0,
+			81, 0, 77, 0, 79, 0, 76, 0, 77, 0, 0, 0, 0, 0, 0, 0,
+		},
+		Bass: []int{53, 53, 57, 57, 58, 58, 55, 55, 50, 50, 55, 55, 53, 53, 53, 53},
+	},
+}
+
+// Render returns one pass of the song as PCM.
+func (song Song) Render() []byte {
+	beat := 60 / song.BPM
+	step := beat / 2
+	notes := []Note{}
+	for i, note := range song.Melody {
+		if note != 0 {
+			notes = append(notes, Note{Freq: midi(note), Start: float64(i) * step, Length: step * 0.9, Volume: 0.12, Wave: song.Lead})
+		}
+	}
+	for i, note := range song.Bass {
+		if note != 0 {
+			notes = append(notes, Note{Freq: midi(note), Start: float64(i) * beat, Length: beat * 0.9, Volume: 0.18, Wave: triangle})
+		}
+	}
+	return renderNotes(notes, float64(len(song.Melody))*step)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Trait int
+
+const (
+	TraitInfantry Trait = iota
+	TraitCavalry
+	TraitClergy
+	TraitFortress
+	TraitRoyalty
+)
+
+var traitNames = map[Trait]string{
+	TraitInfantry: "trait.infantry",
+	TraitCavalry:  "trait.cavalry",
+	TraitClergy:   "trait.clergy",
+	TraitFortress: "trait.fortress",
+	TraitRoyalty:  "trait.royalty",
+}
+
+var pieceTraits = map[Piece][]Trait{
+	PiecePawn:   {TraitInfantry},
+	PieceKnight: {TraitCavalry},
+	PieceBishop: {TraitClergy},
+	PieceRook:   {TraitFortress},
+	PieceQueen:  {TraitRoyalty},
+	PieceKing:   {TraitRoyalty, TraitInfantry},
+}
+
+// Rules holds the per-color tweaks to move generation and evaluation. The
+// zero value is standard chess movement.
+type Rules struct {
+	KnightExtraLeaps    int
+	PromotionRanksEarly int
+	BishopPierce        int
+	KingDoubleMove      bool
+	PieceValueBonus     [pieceCount]float64
+}
+
+type RuleModifier func(rules *Rules)
+
+type Synergy struct {
+	Trait       Trait
+	Count       int
+	Description string
+	Modify      RuleModifier
+}
+
+var synergies = []Synergy{
+	{
+		Trait:       TraitInfantry,
+		Count:       3,
+		Description: "synergy.infantry",
+		Modify: func(rules *Rules) {
+			rules.PromotionRanksEarly += 1
+		},
+	},
+	{
+		Trait:       TraitCavalry,
+		Count:       2,
+		Description: "synergy.cavalry",
+		Modify: func(rules *Rules) {
+			rules.KnightExtraLeaps += 1
+			rules.PieceValueBonus[PieceKnight] += 1
+		},
+	},
+	{
+		Trait:       TraitClergy,
+		Count:       2,
+		Description: "synergy.clergy",
+		Modify: func(rules *Rules) {
+			rules.BishopPierce += 1
+			rules.PieceValueBonus[PieceBishop] += 1
+		},
+	},
+	{
+		Trait:       TraitFortress,
+		Count:       2,
+		Description: "synergy.fortress",
+		Modify: func(rules *Rules) {
+			rules.PieceValueBonus[PieceRook] += 2
+		},
+	},
+}
+
+func (board *Board) traitCounts(color Color) map[Trait]int {
+	counts := map[Trait]int{}
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			tile := board.Tiles[y][x]
+			if tile.Piece == PieceEmpty || tile.Color != color {
+				continue
+			}
+			for _, trait := range pieceTraits[tile.Piece] {
+				counts[trait] += 1
+			}
+		}
+	}
+	return counts
+}
+
+func (board *Board) ActiveSynergies(color Color) []Synergy {
+	counts := board.traitCounts(color)
+	active := []Synergy{}
+	for _, synergy := range synergies {
+		if counts[synergy.Trait] >= synergy.Count {
+			active = append(active, synergy)
+		}
+	}
+	return active
+}
+
+// ActiveRules combines the synergies the color fields on the board. A battle
+// takes them from the arranged board when it starts and keeps them, so a
+// promotion mid-battle does not change which synergies are active.
+func (board *Board) ActiveRules(color Color) Rules {
+	rules := Rules{}
+	for _, synergy := range board.ActiveSynergies(color) {
+		synergy.Modify(&rules)
+	}
+	return rules
+}
+
+func (g *Game) DrawSynergies(screen *ebiten.Image) {
+	counts := g.Board.traitCounts(White)
+	for i, synergy := range synergies {
+		prefix := " "
+		if counts[synergy.Trait] >= synergy.Count {
+			prefix = "+"
+		}
+		content := fmt.Sprintf("%s%s %d/%d", prefix, T(traitNames[synergy.Trait]), counts[synergy.Trait], synergy.Count)
+		g.Graphics.DrawText(screen, content, 4, float64(TileSize*2+i*14))
+	}
+}
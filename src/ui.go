@@ -0,0 +1,250 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type Anchor int
+
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// Layout places a widget relative to an anchor of its parent. X and Y offset
+// the widget from the anchored position; a zero W or H fills the parent.
+type Layout struct {
+	Anchor Anchor
+	X, Y   int
+	W, H   int
+}
+
+func (layout Layout) Resolve(parent image.Rectangle) image.Rectangle {
+	w, h := layout.W, layout.H
+	if w == 0 {
+		w = parent.Dx()
+	}
+	if h == 0 {
+		h = parent.Dy()
+	}
+	col, row := int(layout.Anchor)%3, int(layout.Anchor)/3
+	x := parent.Min.X + (parent.Dx()-w)*col/2 + layout.X
+	y := parent.Min.Y + (parent.Dy()-h)*row/2 + layout.Y
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// Base holds the state every widget shares. A widget takes part in hit
+// testing when it has a handler or a tooltip. OnPress fires as the button
+// goes down, OnClick when it is released over the same widget.
+type Base struct {
+	Layout   Layout
+	Hidden   bool
+	Disabled bool
+	Tooltip  string
+	OnClick  func()
+	OnPress  func()
+
+	rect    image.Rectangle
+	hovered bool
+	pressed bool
+}
+
+func (base *Base) base() *Base { return base }
+
+func (base *Base) Rect() image.Rectangle { return base.rect }
+
+func (base *Base) Hovered() bool { return base.hovered }
+
+func (base *Base) Pressed() bool { return base.pressed }
+
+func (base *Base) interactive() bool {
+	return base.OnClick != nil || base.OnPress != nil || base.Tooltip != ""
+}
+
+type Widget interface {
+	base() *Base
+	Children() []Widget
+	Draw(g *Game, screen *ebiten.Image)
+}
+
+// UI is a retained tree of widgets laid out on the LayoutWidth×LayoutHeight
+// canvas. Refresh runs before each update and draw so widgets can pick up
+// changes to the game.
+type UI struct {
+	Root    *Panel
+	Refresh func()
+
+	hovered Widget
+	pressed Widget
+	hover   int // ticks the hovered widget has been under the cursor
+}
+
+func NewUI(children ...Widget) *UI {
+	return &UI{Root: &Panel{Widgets: children}}
+}
+
+func (ui *UI) Add(children ...Widget) {
+	ui.Root.Widgets = append(ui.Root.Widgets, children...)
+}
+
+// Hovering reports whether the cursor is over an interactive widget.
+func (ui *UI) Hovering() bool {
+	return ui.hovered != nil
+}
+
+func (ui *UI) layout() {
+	if ui.Refresh != nil {
+		ui.Refresh()
+	}
+	layoutWidget(ui.Root, image.Rect(0, 0, LayoutWidth, LayoutHeight))
+}
+
+func (ui *UI) Update() {
+	ui.layout()
+
+	mx, my := ebiten.CursorPosition()
+	cursor := image.Pt(mx, my)
+	hovered := hitWidget(ui.Root, cursor)
+	if hovered != ui.hovered {
+		ui.hover = 0
+	}
+	ui.hover += 1
+	if ui.hovered != nil {
+		ui.hovered.base().hovered = false
+	}
+	ui.hovered = hovered
+	if hovered != nil {
+		hovered.base().hovered = true
+	}
+
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		scrollWidget(ui.Root, cursor, wheel)
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && hovered != nil {
+		ui.pressed = hovered
+		if base := hovered.base(); !base.Disabled && base.OnPress != nil {
+			base.OnPress()
+		}
+	}
+	if ui.pressed != nil {
+		ui.pressed.base().pressed = ui.pressed == hovered
+	}
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && ui.pressed != nil {
+		pressed := ui.pressed.base()
+		pressed.pressed = false
+		ui.pressed = nil
+		if pressed.hovered && !pressed.Disabled && pressed.OnClick != nil {
+			pressed.OnClick()
+		}
+	}
+}
+
+func (ui *UI) Draw(g *Game, screen *ebiten.Image) {
+	ui.layout()
+	drawWidget(g, screen, ui.Root)
+
+	if ui.hovered != nil && ui.hover >= int(TooltipDelay*float64(ebiten.TPS())) {
+		if tooltip := ui.hovered.base().Tooltip; tooltip != "" {
+			mx, my := ebiten.CursorPosition()
+			g.DrawTooltip(screen, tooltip, mx, my)
+		}
+	}
+}
+
+func layoutWidget(widget Widget, parent image.Rectangle) {
+	base := widget.base()
+	base.rect = base.Layout.Resolve(parent)
+	if list, ok := widget.(*ScrollList); ok {
+		for i, item := range list.Items {
+			layoutWidget(item, list.row(i))
+		}
+		return
+	}
+	for _, child := range widget.Children() {
+		layoutWidget(child, base.rect)
+	}
+}
+
+// hitWidget returns the topmost interactive widget under the cursor.
+func hitWidget(widget Widget, cursor image.Point) Widget {
+	base := widget.base()
+	if base.Hidden {
+		return nil
+	}
+	if _, ok := widget.(*ScrollList); ok && !cursor.In(base.rect) {
+		return nil
+	}
+	children := widget.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if hit := hitWidget(children[i], cursor); hit != nil {
+			return hit
+		}
+	}
+	if base.interactive() && cursor.In(base.rect) {
+		return widget
+	}
+	return nil
+}
+
+func scrollWidget(widget Widget, cursor image.Point, wheel float64) {
+	base := widget.base()
+	if base.Hidden || !cursor.In(base.rect) {
+		return
+	}
+	if list, ok := widget.(*ScrollList); ok {
+		list.Scroll(-int(wheel) * list.ItemHeight)
+	}
+	for _, child := range widget.Children() {
+		scrollWidget(child, cursor, wheel)
+	}
+}
+
+func drawWidget(g *Game, screen *ebiten.Image, widget Widget) {
+	base := widget.base()
+	if base.Hidden {
+		return
+	}
+	widget.Draw(g, screen)
+
+	target := screen
+	if _, ok := widget.(*ScrollList); ok {
+		target = screen.SubImage(base.rect).(*ebiten.Image)
+	}
+	for _, child := range widget.Children() {
+		drawWidget(g, target, child)
+	}
+}
+
+var tooltipColor = color.RGBA{0x20, 0x1c, 0x28, 0xe0}
+
+// DrawTooltip draws markup in a box next to the cursor, wrapping it when it
+// is wider than TooltipWidth and keeping the box on the canvas.
+func (g *Game) DrawTooltip(screen *ebiten.Image, content string, x, y int) {
+	lines := WrapText(content, TooltipWidth, TextScaleBody)
+	width := 0.0
+	for _, line := range lines {
+		lineWidth := 0.0
+		for _, span := range line {
+			lineWidth += MeasureText(span.Text, TextScaleBody)
+		}
+		width = max(width, lineWidth)
+	}
+	w, h := int(width)+6, len(lines)*fontLineHeight+4
+	x = max(min(x+8, LayoutWidth-w), 0)
+	y = max(min(y+8, LayoutHeight-h), 0)
+	vector.FillRect(screen, float32(x), float32(y), float32(w), float32(h), tooltipColor, false)
+	g.Graphics.DrawTextBox(screen, content, image.Rect(x+3, y+2, x+w, y+h), TextStyle{})
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type Panel struct {
+	Base
+	Background color.Color // nil for a transparent panel
+	Widgets    []Widget
+}
+
+func (panel *Panel) Children() []Widget { return panel.Widgets }
+
+func (panel *Panel) Draw(g *Game, screen *ebiten.Image) {
+	if panel.Background == nil {
+		return
+	}
+	r := panel.rect
+	vector.FillRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), float32(r.Dy()), panel.Background, false)
+}
+
+// Label draws markup on a single line centered vertically in its bounds, or
+// word-wrapped from the top when Wrap is set.
+type Label struct {
+	Base
+	Text  string
+	Style TextStyle
+	Wrap  bool
+}
+
+func (label *Label) Children() []Widget { return nil }
+
+func (label *Label) Draw(g *Game, screen *ebiten.Image) {
+	r := label.rect
+	if label.Wrap {
+		g.Graphics.DrawTextBox(screen, label.Text, r, label.Style)
+		return
+	}
+	x := float64(r.Min.X)
+	switch label.Style.Align {
+	case text.AlignCenter:
+		x = float64(r.Min.X+r.Max.X) / 2
+	case text.AlignEnd:
+		x = float64(r.Max.X)
+	}
+	scale := label.Style.scale()
+	y := float64(r.Min.Y) + (float64(r.Dy())-fontLineHeight*scale)/2 + fontAscent*scale
+	g.Graphics.DrawTextStyled(screen, label.Text, x, y, label.Style)
+}
+
+// Button draws a background sprite with an optional icon or text on top. It
+// brightens while hovered, sinks while pressed and dims when disabled.
+// Buttons wider than a sprite fill their bounds with Background instead.
+type Button struct {
+	Base
+	Sprite     *ebiten.Image
+	Background color.RGBA
+	Icon       *ebiten.Image
+	Text       string
+	Tint       ebiten.ColorScale
+	Selected   bool
+}
+
+func (button *Button) Children() []Widget { return nil }
+
+func (button *Button) Draw(g *Game, screen *ebiten.Image) {
+	r := button.rect
+	x, y := float64(r.Min.X), float64(r.Min.Y)
+	if button.pressed {
+		y += 1
+	}
+
+	shade := float32(1)
+	switch {
+	case button.Disabled:
+		shade = 0.5
+	case button.pressed:
+		shade = 0.85
+	case button.hovered:
+		shade = 1.2
+	}
+	opt := g.Graphics.Position(x, y)
+	opt.ColorScale = button.Tint
+	opt.ColorScale.Scale(shade, shade, shade, 1)
+	if button.Sprite != nil {
+		screen.DrawImage(button.Sprite, &opt)
+	} else if button.Background.A > 0 {
+		clr := button.Background
+		scale := func(c uint8) uint8 { return uint8(min(float32(c)*shade, 0xff)) }
+		clr.R, clr.G, clr.B = scale(clr.R), scale(clr.G), scale(clr.B)
+		vector.FillRect(screen, float32(x), float32(y), float32(r.Dx()), float32(r.Dy()), clr, false)
+	}
+	if button.Icon != nil {
+		screen.DrawImage(button.Icon, &opt)
+	}
+	if button.Text != "" {
+		style := TextStyle{Align: text.AlignCenter}
+		g.Graphics.DrawTextStyled(screen, button.Text, x+float64(r.Dx())/2, y+12, style)
+	}
+	if button.Selected {
+		drawSelection(g, screen, r)
+	}
+}
+
+// CardSlot shows a single card and highlights it when selected.
+type CardSlot struct {
+	Base
+	Card     Card
+	Selected bool
+}
+
+func (slot *CardSlot) Children() []Widget { return nil }
+
+func (slot *CardSlot) Draw(g *Game, screen *ebiten.Image) {
+	x, y := float64(slot.rect.Min.X), float64(slot.rect.Min.Y)
+	g.DrawCard(screen, slot.Card, x, y)
+	if slot.Selected || slot.hovered {
+		opt := g.Graphics.Position(x, y)
+		if !slot.Selected {
+			opt.ColorScale.ScaleAlpha(0.5)
+		}
+		screen.DrawImage(Sprites[SpriteHover], &opt)
+	}
+}
+
+// ScrollList stacks its items in rows of ItemHeight and clips them to its
+// own bounds. The mouse wheel scrolls it while the cursor is over it.
+type ScrollList struct {
+	Base
+	Items      []Widget
+	ItemHeight int
+	Offset     int
+}
+
+func (list *ScrollList) Children() []Widget { return list.Items }
+
+func (list *ScrollList) Draw(g *Game, screen *ebiten.Image) {}
+
+func (list *ScrollList) row(i int) image.Rectangle {
+	y := list.rect.Min.Y + i*list.ItemHeight - list.Offset
+	return image.Rect(list.rect.Min.X, y, list.rect.Max.X, y+list.ItemHeight)
+}
+
+func (list *ScrollList) Scroll(delta int) {
+	limit := max(len(list.Items)*list.ItemHeight-list.rect.Dy(), 0)
+	list.Offset = min(max(list.Offset+delta, 0), limit)
+}
+
+func drawSelection(g *Game, screen *ebiten.Image, r image.Rectangle) {
+	opt := g.Graphics.Position(0, 0)
+	opt.GeoM.Scale(float64(r.Dx())/TileSize, float64(r.Dy())/TileSize)
+	opt.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	screen.DrawImage(Sprites[SpriteHover], &opt)
+}
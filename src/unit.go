@@ -0,0 +1,135 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+)
+
+type UnitID int // 0 means the piece is not a persistent unit
+
+type Unit struct {
+	ID      UnitID
+	Name    string
+	Kills   int
+	XP      int
+	Battles int
+}
+
+var unitNames = []string{
+	"Ada", "Bjorn", "Cora", "Dag", "Eira", "Finn", "Gro", "Hakon",
+	"Ingrid", "Jarl", "Kari", "Leif", "Maren", "Njal", "Oda", "Per",
+	"Ragna", "Sigrid", "Tor", "Ulf", "Vidar", "Ylva",
+}
+
+// Rank grows with experience and carries over between battles.
+func (unit *Unit) Rank() int {
+	return 1 + unit.XP/XPPerRank
+}
+
+func (unit *Unit) String() string {
+	return T("unit.summary", "name", unit.Name, "rank", unit.Rank(), "count", unit.Kills)
+}
+
+// Recruit creates a new unit. Its name is drawn from the run's seed, so a
+// replayed seed recruits the same names.
+func (g *Game) Recruit() UnitID {
+	g.NextUnitID += 1
+	id := g.NextUnitID
+	rng := rand.New(rand.NewSource(g.Seed + int64(id)))
+	g.Units[id] = &Unit{ID: id, Name: unitNames[rng.Intn(len(unitNames))]}
+	return id
+}
+
+func (g *Game) AddToDeck(card Card) {
+	if !card.IsSpell() && card.Unit == 0 {
+		card.Unit = g.Recruit()
+	}
+	g.Deck.Cards = mergeCards(append(g.Deck.Cards, card), g.absorbUnits)
+	g.syncHand()
+}
+
+// syncHand refreshes unit cards in the hand from the deck, dropping any whose
+// unit was merged away.
+func (g *Game) syncHand() {
+	cards := []Card{}
+	for _, held := range g.Hand.Cards {
+		if held.Unit == 0 {
+			cards = append(cards, held)
+			continue
+		}
+		idx := slices.IndexFunc(g.Deck.Cards, func(card Card) bool { return card.Unit == held.Unit })
+		if idx >= 0 {
+			cards = append(cards, g.Deck.Cards[idx])
+		}
+	}
+	g.Hand.Cards = cards
+	g.Hand.SelectIndex = -1
+	g.Hand.Targets = nil
+}
+
+// absorbUnits folds the experience of merged-away units into the unit that
+// survives the merge.
+func (g *Game) absorbUnits(kept Card, absorbed []Card) {
+	unit, ok := g.Units[kept.Unit]
+	for _, card := range absorbed {
+		other, found := g.Units[card.Unit]
+		if !found {
+			continue
+		}
+		if ok {
+			unit.Kills += other.Kills
+			unit.XP += other.XP
+		}
+		delete(g.Units, card.Unit)
+	}
+}
+
+func (g *Game) RecordKill(killer Tile) {
+	if unit, ok := g.Units[killer.Unit]; ok {
+		unit.Kills += 1
+		unit.XP += XPPerKill
+	}
+}
+
+func (g *Game) RecordCasualty(victim Tile) {
+	if victim.Unit != 0 {
+		g.Casualties = append(g.Casualties, victim.Unit)
+	}
+}
+
+// deployedUnits lists the persistent units currently on the board.
+func (board *Board) deployedUnits() []UnitID {
+	units := []UnitID{}
+	for y := range BoardHeight {
+		for x := range BoardWidth {
+			tile := board.Tiles[y][x]
+			if tile.Piece != PieceEmpty && tile.Unit != 0 {
+				units = append(units, tile.Unit)
+			}
+		}
+	}
+	return units
+}
+
+// SettleUnits hands out battle experience and, with permadeath, removes the
+// units that were captured from the deck and the hand.
+func (g *Game) SettleUnits() {
+	for _, id := range g.Deployed {
+		if unit, ok := g.Units[id]; ok {
+			unit.Battles += 1
+			unit.XP += XPPerBattle
+		}
+	}
+
+	if g.Permadeath {
+		for _, id := range g.Casualties {
+			isDead := func(card Card) bool { return card.Unit == id }
+			g.Deck.Cards = slices.DeleteFunc(g.Deck.Cards, isDead)
+			g.Hand.Cards = slices.DeleteFunc(g.Hand.Cards, isDead)
+			delete(g.Units, id)
+		}
+		g.Hand.SelectIndex = -1
+	}
+	g.Deployed = nil
+	g.Casualties = nil
+}